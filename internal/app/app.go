@@ -62,12 +62,21 @@ func _genExamples() string {
   # Show the full process ancestry (who started whom)
   witr postgres --tree
 
+  # Include every descendant, with CPU/memory roll-ups per subtree
+  witr php-fpm --tree --depth 0
+
   # Show only warnings (suspicious env, arguments, parents)
   witr docker --warnings
 
   # Display only environment variables of the process
   witr node --env
 
+  # Show which variables the process added or changed versus its parent and unit
+  witr node --env --diff
+
+  # Look for processes hidden from /proc listings (run as root)
+  witr --audit
+
   # Short, single-line output (useful for scripts)
   witr sshd --short
 
@@ -116,20 +125,35 @@ func init() {
 	rootCmd.Flags().StringP("file", "f", "", "file path to find process for")
 	rootCmd.Flags().BoolP("short", "s", false, "show only ancestry")
 	rootCmd.Flags().BoolP("tree", "t", false, "show only ancestry as a tree")
+	rootCmd.Flags().Int("depth", 1, "descendant levels to expand with --tree (0 for unlimited)")
 	rootCmd.Flags().Bool("json", false, "show result as JSON")
 	rootCmd.Flags().Bool("warnings", false, "show only warnings")
 	rootCmd.Flags().Bool("no-color", false, "disable colorized output")
 	rootCmd.Flags().Bool("env", false, "show environment variables for the process")
+	rootCmd.Flags().Bool("diff", false, "with --env, show only variables added or changed relative to the parent and systemd unit")
 	rootCmd.Flags().Bool("verbose", false, "show extended process information")
 	rootCmd.Flags().BoolP("exact", "x", false, "use exact name matching (no substring search)")
 	rootCmd.Flags().BoolP("interactive", "i", false, "interactive mode (TUI)")
+	rootCmd.Flags().Bool("audit", false, "scan for processes hidden from the /proc listing (Linux)")
+	rootCmd.Flags().Bool("reveal", false, "show secrets in environment variables and command lines instead of redacting them")
+	rootCmd.Flags().StringArray("redact", nil, "extra variable or flag name pattern to redact (regex, repeatable; also read from WITR_REDACT)")
 
 }
 
 func runApp(cmd *cobra.Command, args []string) error {
+	redactor, err := buildRedactor(cmd)
+	if err != nil {
+		return err
+	}
+
 	interactiveFlag, _ := cmd.Flags().GetBool("interactive")
 	if interactiveFlag {
-		return runInteractive()
+		return runInteractive(redactor)
+	}
+
+	auditFlag, _ := cmd.Flags().GetBool("audit")
+	if auditFlag {
+		return runAudit(cmd, redactor)
 	}
 
 	envFlag, _ := cmd.Flags().GetBool("env")
@@ -138,10 +162,11 @@ func runApp(cmd *cobra.Command, args []string) error {
 	fileFlag, _ := cmd.Flags().GetString("file")
 	// Default to interactive mode if no arguments or relevant flags are provided
 	if !envFlag && pidFlag == "" && portFlag == "" && fileFlag == "" && len(args) == 0 {
-		return runInteractive()
+		return runInteractive(redactor)
 	}
 	shortFlag, _ := cmd.Flags().GetBool("short")
 	treeFlag, _ := cmd.Flags().GetBool("tree")
+	depthFlag, _ := cmd.Flags().GetInt("depth")
 	jsonFlag, _ := cmd.Flags().GetBool("json")
 	warnFlag, _ := cmd.Flags().GetBool("warnings")
 	noColorFlag, _ := cmd.Flags().GetBool("no-color")
@@ -183,6 +208,7 @@ func runApp(cmd *cobra.Command, args []string) error {
 					command = proc.Command
 					cmdline = proc.Cmdline
 				}
+				cmdline = redactor.Cmdline(cmdline)
 				if !noColorFlag {
 					outp.Printf("[%d] %s%s%s (%spid %d%s)\n    %s\n",
 						i+1, output.ColorGreen, command, output.ColorReset,
@@ -202,11 +228,38 @@ func runApp(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("error: %v", err)
 		}
 
+		// Compare before redacting so masked values do not all look changed
+		diffFlag, _ := cmd.Flags().GetBool("diff")
+		var envDiff *model.EnvDiff
+		if diffFlag {
+			parent, err := procpkg.ReadProcess(procInfo.PPID)
+			if err != nil {
+				parent = model.Process{PID: procInfo.PPID}
+			}
+			envDiff = source.ExplainEnv(procInfo, parent)
+			redactor.EnvVars(envDiff.Vars)
+		}
+
+		redactor.Process(&procInfo)
+
 		resEnv := model.Result{
 			Process:  procInfo,
 			Ancestry: []model.Process{procInfo},
 		}
 
+		if envDiff != nil {
+			if jsonFlag {
+				importJSON, err := output.ToEnvDiffJSON(resEnv, envDiff)
+				if err != nil {
+					return fmt.Errorf("failed to generate json output: %w", err)
+				}
+				fmt.Fprintln(outw, importJSON)
+			} else {
+				output.RenderEnvDiff(outw, resEnv, envDiff, !noColorFlag)
+			}
+			return nil
+		}
+
 		if jsonFlag {
 			importJSON, err := output.ToEnvJSON(resEnv)
 			if err != nil {
@@ -281,6 +334,7 @@ func runApp(cmd *cobra.Command, args []string) error {
 				command = proc.Command
 				cmdline = proc.Cmdline
 			}
+			cmdline = redactor.Cmdline(cmdline)
 			if !noColorFlag {
 				outp.Printf("[%d] %s%s%s (%spid %d%s)\n    %s\n",
 					i+1, output.ColorGreen, command, output.ColorReset,
@@ -316,6 +370,7 @@ func runApp(cmd *cobra.Command, args []string) error {
 		PID:     pid,
 		Verbose: verboseFlag,
 		Tree:    treeFlag,
+		Depth:   depthFlag,
 		Target:  t,
 	})
 
@@ -330,6 +385,8 @@ func runApp(cmd *cobra.Command, args []string) error {
 		res.ResolvedTarget = strings.TrimSuffix(systemdService, ".service")
 	}
 
+	redactor.Result(&res)
+
 	// Add socket state info for port queries
 	if t.Type == model.TargetPort {
 		portNum := 0
@@ -361,7 +418,7 @@ func runApp(cmd *cobra.Command, args []string) error {
 	} else if warnFlag {
 		output.RenderWarnings(outw, res, !noColorFlag)
 	} else if treeFlag {
-		output.PrintTree(outw, res.Ancestry, res.Children, !noColorFlag)
+		output.PrintTree(outw, res.Ancestry, res.Tree, !noColorFlag)
 	} else if shortFlag {
 		output.RenderShort(outw, res, !noColorFlag)
 	} else {
@@ -370,14 +427,53 @@ func runApp(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// runAudit scans for processes that are alive but hidden from /proc listings
+func runAudit(cmd *cobra.Command, redactor *output.Redactor) error {
+	jsonFlag, _ := cmd.Flags().GetBool("json")
+	noColorFlag, _ := cmd.Flags().GetBool("no-color")
+	outw := cmd.OutOrStdout()
+
+	res, err := procpkg.AuditHiddenProcesses()
+	if err != nil {
+		return fmt.Errorf("audit failed: %w", err)
+	}
+	for i := range res.Hidden {
+		res.Hidden[i].Cmdline = redactor.Cmdline(res.Hidden[i].Cmdline)
+	}
+
+	if jsonFlag {
+		importJSON, err := output.ToAuditJSON(res)
+		if err != nil {
+			return fmt.Errorf("failed to generate json output: %w", err)
+		}
+		fmt.Fprintln(outw, importJSON)
+	} else {
+		output.RenderAudit(outw, res, !noColorFlag)
+	}
+	return nil
+}
+
 func Root() *cobra.Command { return rootCmd }
 
-func runInteractive() error {
+func runInteractive(redactor *output.Redactor) error {
 	v := version
 	if v == "v0.0.0-dev" {
 		v = ""
 	}
-	return tui.Start(v)
+	return tui.Start(v, redactor)
+}
+
+// buildRedactor returns the secret redactor for output, or nil with --reveal
+func buildRedactor(cmd *cobra.Command) (*output.Redactor, error) {
+	revealFlag, _ := cmd.Flags().GetBool("reveal")
+	if revealFlag {
+		return nil, nil
+	}
+	patterns, _ := cmd.Flags().GetStringArray("redact")
+	if env := os.Getenv("WITR_REDACT"); env != "" {
+		patterns = append(patterns, strings.Split(env, ",")...)
+	}
+	return output.NewRedactor(patterns)
 }
 
 func SetVersionBuildCommitString(Version string, Commit string, BuildDate string) {
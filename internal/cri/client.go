@@ -0,0 +1,176 @@
+// Package cri queries a Kubernetes node's container runtime (containerd,
+// CRI-O or cri-dockerd) over its CRI socket: gRPC on a unix socket, spoken
+// here with net/http's unencrypted HTTP/2 and a minimal protobuf codec, so
+// pod details are available without crictl or access to the cluster API.
+package cri
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Timeout bounds every runtime call
+const Timeout = 3 * time.Second
+
+const runtimeService = "/runtime.v1.RuntimeService/"
+
+// Client is a connection to one CRI runtime socket
+type Client struct {
+	Endpoint string
+	http     *http.Client
+}
+
+var (
+	clientsOnce sync.Once
+	clients     []*Client
+)
+
+// ErrNoSocket is returned when no CRI socket is configured or present
+var ErrNoSocket = errors.New("no CRI runtime socket found")
+
+// Connect returns clients for CONTAINER_RUNTIME_ENDPOINT, or else for every
+// well-known runtime socket that exists. A node may run more than one
+// containerd, so callers try each until one knows the container.
+func Connect() ([]*Client, error) {
+	clientsOnce.Do(func() {
+		if ep := os.Getenv("CONTAINER_RUNTIME_ENDPOINT"); ep != "" {
+			if c, err := New(ep); err == nil {
+				clients = append(clients, c)
+			}
+			return
+		}
+		for _, p := range socketPaths {
+			if info, err := os.Stat(p); err == nil && info.Mode()&os.ModeSocket != 0 {
+				if c, err := New("unix://" + p); err == nil {
+					clients = append(clients, c)
+				}
+			}
+		}
+	})
+	if len(clients) == 0 {
+		return nil, ErrNoSocket
+	}
+	return clients, nil
+}
+
+// socketPaths are the CRI endpoints crictl probes, plus those of k3s and
+// MicroK8s
+var socketPaths = []string{
+	"/run/containerd/containerd.sock",
+	"/run/crio/crio.sock",
+	"/run/cri-dockerd.sock",
+	"/run/k3s/containerd/containerd.sock",
+	"/var/snap/microk8s/common/run/containerd.sock",
+}
+
+// New creates a client for a unix:// endpoint or a bare socket path
+func New(endpoint string) (*Client, error) {
+	path := endpoint
+	if strings.Contains(endpoint, "://") {
+		u, err := url.Parse(endpoint)
+		if err != nil {
+			return nil, fmt.Errorf("invalid endpoint %q: %w", endpoint, err)
+		}
+		if u.Scheme != "unix" {
+			return nil, fmt.Errorf("unsupported endpoint %q", endpoint)
+		}
+		path = u.Path
+	}
+
+	// gRPC needs HTTP/2 with prior knowledge on the cleartext socket
+	protocols := new(http.Protocols)
+	protocols.SetUnencryptedHTTP2(true)
+	transport := &http.Transport{
+		Protocols: protocols,
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, "unix", path)
+		},
+	}
+	return &Client{Endpoint: path, http: &http.Client{Transport: transport, Timeout: Timeout}}, nil
+}
+
+// Error is a non-OK gRPC status
+type Error struct {
+	Code    int
+	Message string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("CRI: status %d: %s", e.Code, e.Message)
+}
+
+// codeNotFound is the gRPC status of a lookup for an unknown ID
+const codeNotFound = 5
+
+// IsNotFound reports whether err is a NotFound status
+func IsNotFound(err error) bool {
+	var e *Error
+	return errors.As(err, &e) && e.Code == codeNotFound
+}
+
+// call sends one unary RPC and returns the response message
+func (c *Client) call(ctx context.Context, method string, msg []byte) ([]byte, error) {
+	body := make([]byte, 5, 5+len(msg))
+	binary.BigEndian.PutUint32(body[1:], uint32(len(msg)))
+	body = append(body, msg...)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "http://localhost"+runtimeService+method, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/grpc")
+	req.Header.Set("TE", "trailers")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("CRI: HTTP %s", resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 16<<20))
+	if err != nil {
+		return nil, err
+	}
+
+	// A failed call may carry its status in the headers alone
+	status := resp.Trailer.Get("Grpc-Status")
+	message := resp.Trailer.Get("Grpc-Message")
+	if status == "" {
+		status = resp.Header.Get("Grpc-Status")
+		message = resp.Header.Get("Grpc-Message")
+	}
+	if status != "" && status != "0" {
+		code, _ := strconv.Atoi(status)
+		if m, err := url.PathUnescape(message); err == nil {
+			message = m
+		}
+		return nil, &Error{Code: code, Message: message}
+	}
+
+	if len(data) < 5 {
+		return nil, errTruncated
+	}
+	if data[0] != 0 {
+		return nil, errors.New("CRI: compressed responses are not supported")
+	}
+	n := binary.BigEndian.Uint32(data[1:5])
+	if uint32(len(data)-5) < n {
+		return nil, errTruncated
+	}
+	return data[5 : 5+n], nil
+}
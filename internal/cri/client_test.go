@@ -0,0 +1,157 @@
+package cri
+
+import (
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"net/http"
+	"path/filepath"
+	"testing"
+)
+
+const (
+	ctrID = "9b1d3c0e5f7a2b4c6d8e0f1a3b5c7d9e1f3a5b7c9d1e3f5a7b9c1d3e5f7a9b1d"
+	podID = "44c2e1d0aa"
+)
+
+func mapEntry(field int, k, v string) []byte {
+	return appendMessage(nil, field, appendString(appendString(nil, 1, k), 2, v))
+}
+
+func containerMsg() []byte {
+	var b []byte
+	b = appendString(b, 1, ctrID)
+	b = appendString(b, 2, podID)
+	meta := appendString(nil, 1, "app")
+	meta = appendTag(meta, 2, wireVarint)
+	meta = binary.AppendUvarint(meta, 3)
+	b = appendMessage(b, 3, meta)
+	b = appendMessage(b, 4, appendString(nil, 1, "ghcr.io/acme/api:1.4"))
+	b = appendTag(b, 7, wireVarint) // created_at, ignored
+	b = binary.AppendUvarint(b, 1700000000)
+	b = append(b, mapEntry(8, LabelPodName, "api-7f9c5d4b8-x2x9z")...)
+	b = append(b, mapEntry(8, LabelPodNamespace, "default")...)
+	return b
+}
+
+func sandboxMsg() []byte {
+	var s []byte
+	s = appendString(s, 1, podID)
+	meta := appendString(nil, 1, "api-7f9c5d4b8-x2x9z")
+	meta = appendString(meta, 2, "1b4e28ba-2fa1-11d2-883f-0016d3cca427")
+	meta = appendString(meta, 3, "default")
+	s = appendMessage(s, 2, meta)
+	s = append(s, mapEntry(7, "app", "api")...)
+	s = append(s, mapEntry(7, "pod-template-hash", "7f9c5d4b8")...)
+	s = append(s, mapEntry(8, "kubernetes.io/config.source", "api")...)
+	return appendMessage(nil, 1, s)
+}
+
+// fakeRuntime serves canned CRI replies over h2c on a unix socket
+func fakeRuntime(t *testing.T) *Client {
+	t.Helper()
+	sock := filepath.Join(t.TempDir(), "containerd.sock")
+	ln, err := net.Listen("unix", sock)
+	if err != nil {
+		t.Skipf("unix sockets unavailable: %v", err)
+	}
+
+	reply := func(w http.ResponseWriter, msg []byte) {
+		w.Header().Set("Content-Type", "application/grpc")
+		w.Header().Set("Trailer", "Grpc-Status")
+		frame := make([]byte, 5)
+		binary.BigEndian.PutUint32(frame[1:], uint32(len(msg)))
+		w.Write(append(frame, msg...))
+		w.Header().Set("Grpc-Status", "0")
+	}
+	request := func(r *http.Request) string {
+		body, _ := io.ReadAll(r.Body)
+		var id string
+		decode(body[5:], func(f field) error {
+			id = string(f.data)
+			if f.num == 1 && r.URL.Path == runtimeService+"ListContainers" {
+				decode(f.data, func(g field) error {
+					id = string(g.data)
+					return nil
+				})
+			}
+			return nil
+		})
+		return id
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST "+runtimeService+"ListContainers", func(w http.ResponseWriter, r *http.Request) {
+		if request(r) != ctrID {
+			reply(w, nil)
+			return
+		}
+		reply(w, appendMessage(nil, 1, containerMsg()))
+	})
+	mux.HandleFunc("POST "+runtimeService+"PodSandboxStatus", func(w http.ResponseWriter, r *http.Request) {
+		if request(r) != podID {
+			w.Header().Set("Content-Type", "application/grpc")
+			w.Header().Set("Grpc-Status", "5")
+			w.Header().Set("Grpc-Message", "pod%20sandbox%20not%20found")
+			return
+		}
+		reply(w, sandboxMsg())
+	})
+
+	protocols := new(http.Protocols)
+	protocols.SetUnencryptedHTTP2(true)
+	srv := &http.Server{Handler: mux, Protocols: protocols}
+	go srv.Serve(ln)
+	t.Cleanup(func() { srv.Close() })
+
+	c, err := New("unix://" + sock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestContainer(t *testing.T) {
+	c := fakeRuntime(t)
+	ctr, err := c.Container(context.Background(), ctrID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ctr == nil {
+		t.Fatal("container not found")
+	}
+	if ctr.PodSandboxID != podID || ctr.Name != "app" || ctr.Attempt != 3 || ctr.Image != "ghcr.io/acme/api:1.4" {
+		t.Errorf("unexpected container: %+v", ctr)
+	}
+	if ctr.Labels[LabelPodName] != "api-7f9c5d4b8-x2x9z" || ctr.Labels[LabelPodNamespace] != "default" {
+		t.Errorf("labels = %v", ctr.Labels)
+	}
+
+	ctr, err = c.Container(context.Background(), "deadbeef")
+	if err != nil || ctr != nil {
+		t.Errorf("unknown container = %+v, %v; want nil, nil", ctr, err)
+	}
+}
+
+func TestPodSandbox(t *testing.T) {
+	c := fakeRuntime(t)
+	pod, err := c.PodSandbox(context.Background(), podID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pod.Name != "api-7f9c5d4b8-x2x9z" || pod.Namespace != "default" || pod.UID != "1b4e28ba-2fa1-11d2-883f-0016d3cca427" {
+		t.Errorf("unexpected sandbox: %+v", pod)
+	}
+	if pod.Labels["pod-template-hash"] != "7f9c5d4b8" || pod.Annotations["kubernetes.io/config.source"] != "api" {
+		t.Errorf("labels = %v, annotations = %v", pod.Labels, pod.Annotations)
+	}
+
+	_, err = c.PodSandbox(context.Background(), "gone")
+	if !IsNotFound(err) {
+		t.Fatalf("err = %v, want NotFound", err)
+	}
+	if err.Error() != "CRI: status 5: pod sandbox not found" {
+		t.Errorf("err = %q", err)
+	}
+}
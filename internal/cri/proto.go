@@ -0,0 +1,110 @@
+package cri
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// Just enough protobuf wire format for the handful of CRI messages witr sends
+// and reads: varints, strings, nested messages and map<string, string>.
+
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+	wireFixed32 = 5
+)
+
+var errTruncated = errors.New("truncated protobuf message")
+
+func appendTag(b []byte, field, wire int) []byte {
+	return binary.AppendUvarint(b, uint64(field)<<3|uint64(wire))
+}
+
+// appendString encodes a string field, omitting it when empty as proto3 does
+func appendString(b []byte, field int, s string) []byte {
+	if s == "" {
+		return b
+	}
+	b = appendTag(b, field, wireBytes)
+	b = binary.AppendUvarint(b, uint64(len(s)))
+	return append(b, s...)
+}
+
+// appendMessage encodes an embedded message field
+func appendMessage(b []byte, field int, msg []byte) []byte {
+	b = appendTag(b, field, wireBytes)
+	b = binary.AppendUvarint(b, uint64(len(msg)))
+	return append(b, msg...)
+}
+
+// field is one decoded field: n holds varints, data length-delimited values
+type field struct {
+	num  int
+	wire int
+	n    uint64
+	data []byte
+}
+
+// decode walks the fields of a message, calling fn for each. Fixed-width
+// fields are skipped.
+func decode(b []byte, fn func(f field) error) error {
+	for len(b) > 0 {
+		key, k := binary.Uvarint(b)
+		if k <= 0 {
+			return errTruncated
+		}
+		b = b[k:]
+		f := field{num: int(key >> 3), wire: int(key & 7)}
+		switch f.wire {
+		case wireVarint:
+			v, k := binary.Uvarint(b)
+			if k <= 0 {
+				return errTruncated
+			}
+			f.n, b = v, b[k:]
+		case wireBytes:
+			l, k := binary.Uvarint(b)
+			if k <= 0 || uint64(len(b)-k) < l {
+				return errTruncated
+			}
+			f.data, b = b[k:k+int(l)], b[k+int(l):]
+		case wireFixed64:
+			if len(b) < 8 {
+				return errTruncated
+			}
+			b = b[8:]
+			continue
+		case wireFixed32:
+			if len(b) < 4 {
+				return errTruncated
+			}
+			b = b[4:]
+			continue
+		default:
+			return errors.New("unsupported protobuf wire type")
+		}
+		if err := fn(f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// decodeMapEntry adds one map<string, string> entry to m
+func decodeMapEntry(b []byte, m map[string]string) error {
+	var key, value string
+	err := decode(b, func(f field) error {
+		switch f.num {
+		case 1:
+			key = string(f.data)
+		case 2:
+			value = string(f.data)
+		}
+		return nil
+	})
+	if err == nil {
+		m[key] = value
+	}
+	return err
+}
@@ -0,0 +1,132 @@
+package cri
+
+import "context"
+
+// Labels kubelet puts on every container it creates
+const (
+	LabelPodName       = "io.kubernetes.pod.name"
+	LabelPodNamespace  = "io.kubernetes.pod.namespace"
+	LabelPodUID        = "io.kubernetes.pod.uid"
+	LabelContainerName = "io.kubernetes.container.name"
+)
+
+// Container is the subset of a CRI Container that witr reports
+type Container struct {
+	ID           string
+	PodSandboxID string
+	Name         string
+	Attempt      int // restarts of this container within the pod
+	Image        string
+	Labels       map[string]string
+	Annotations  map[string]string
+}
+
+// PodSandbox is the subset of a CRI PodSandboxStatus that witr reports.
+// Labels are the pod's own labels.
+type PodSandbox struct {
+	ID          string
+	Name        string
+	UID         string
+	Namespace   string
+	Labels      map[string]string
+	Annotations map[string]string
+}
+
+// Container looks up a container by its full ID. It returns nil when the
+// runtime does not know it.
+func (c *Client) Container(ctx context.Context, id string) (*Container, error) {
+	// ListContainersRequest{filter: ContainerFilter{id}}
+	req := appendMessage(nil, 1, appendString(nil, 1, id))
+	resp, err := c.call(ctx, "ListContainers", req)
+	if err != nil {
+		return nil, err
+	}
+
+	var ctr *Container
+	err = decode(resp, func(f field) error {
+		if f.num != 1 || ctr != nil {
+			return nil
+		}
+		var err error
+		ctr, err = decodeContainer(f.data)
+		return err
+	})
+	return ctr, err
+}
+
+func decodeContainer(b []byte) (*Container, error) {
+	ctr := &Container{Labels: map[string]string{}, Annotations: map[string]string{}}
+	err := decode(b, func(f field) error {
+		switch f.num {
+		case 1:
+			ctr.ID = string(f.data)
+		case 2:
+			ctr.PodSandboxID = string(f.data)
+		case 3: // ContainerMetadata
+			return decode(f.data, func(m field) error {
+				switch m.num {
+				case 1:
+					ctr.Name = string(m.data)
+				case 2:
+					ctr.Attempt = int(m.n)
+				}
+				return nil
+			})
+		case 4: // ImageSpec
+			return decode(f.data, func(m field) error {
+				if m.num == 1 {
+					ctr.Image = string(m.data)
+				}
+				return nil
+			})
+		case 8:
+			return decodeMapEntry(f.data, ctr.Labels)
+		case 9:
+			return decodeMapEntry(f.data, ctr.Annotations)
+		}
+		return nil
+	})
+	return ctr, err
+}
+
+// PodSandbox returns the status of a pod sandbox
+func (c *Client) PodSandbox(ctx context.Context, id string) (*PodSandbox, error) {
+	resp, err := c.call(ctx, "PodSandboxStatus", appendString(nil, 1, id))
+	if err != nil {
+		return nil, err
+	}
+
+	pod := &PodSandbox{Labels: map[string]string{}, Annotations: map[string]string{}}
+	err = decode(resp, func(f field) error {
+		if f.num != 1 {
+			return nil
+		}
+		return decode(f.data, func(s field) error {
+			switch s.num {
+			case 1:
+				pod.ID = string(s.data)
+			case 2: // PodSandboxMetadata
+				return decode(s.data, func(m field) error {
+					switch m.num {
+					case 1:
+						pod.Name = string(m.data)
+					case 2:
+						pod.UID = string(m.data)
+					case 3:
+						pod.Namespace = string(m.data)
+					}
+					return nil
+				})
+			case 7:
+				return decodeMapEntry(s.data, pod.Labels)
+			case 8:
+				return decodeMapEntry(s.data, pod.Annotations)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return pod, nil
+}
@@ -0,0 +1,215 @@
+// Package docker talks to the Docker Engine API, or Podman's compatible API,
+// over its unix socket. It replaces `docker inspect` and `docker ps` calls so
+// container details are available when only the socket is.
+package docker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Timeout bounds every API request
+const Timeout = 3 * time.Second
+
+// Client is a connection to one Engine API endpoint
+type Client struct {
+	http *http.Client
+	base string
+}
+
+var (
+	clientsMu sync.Mutex
+	clients   = map[string]*Client{}
+)
+
+// ErrNoSocket is returned when no API endpoint is configured or present
+var ErrNoSocket = errors.New("no container engine socket found")
+
+// Connect returns the shared client for runtime ("docker" or "podman"),
+// honouring DOCKER_HOST / CONTAINER_HOST and otherwise using the first
+// well-known socket that exists.
+func Connect(runtime string) (*Client, error) {
+	clientsMu.Lock()
+	defer clientsMu.Unlock()
+	if c, ok := clients[runtime]; ok {
+		return c, nil
+	}
+
+	host := ""
+	switch runtime {
+	case "docker":
+		host = os.Getenv("DOCKER_HOST")
+	case "podman":
+		host = os.Getenv("CONTAINER_HOST")
+	}
+	if host == "" {
+		for _, p := range socketPaths(runtime) {
+			if info, err := os.Stat(p); err == nil && info.Mode()&os.ModeSocket != 0 {
+				host = "unix://" + p
+				break
+			}
+		}
+	}
+	if host == "" {
+		return nil, ErrNoSocket
+	}
+
+	c, err := New(host)
+	if err != nil {
+		return nil, err
+	}
+	clients[runtime] = c
+	return c, nil
+}
+
+// socketPaths lists where each engine puts its API socket: the system
+// daemons, rootless Podman, Docker Desktop, Colima and Rancher Desktop.
+// Docker clients also accept Podman's Docker-compatible socket.
+func socketPaths(runtime string) []string {
+	home, _ := os.UserHomeDir()
+	runtimeDir := os.Getenv("XDG_RUNTIME_DIR")
+	if runtimeDir == "" {
+		runtimeDir = fmt.Sprintf("/run/user/%d", os.Getuid())
+	}
+
+	podman := []string{
+		filepath.Join(runtimeDir, "podman/podman.sock"),
+		"/run/podman/podman.sock",
+	}
+	if runtime == "podman" {
+		return podman
+	}
+
+	paths := []string{"/var/run/docker.sock", "/run/docker.sock"}
+	if home != "" {
+		paths = append(paths,
+			filepath.Join(home, ".docker/run/docker.sock"),
+			filepath.Join(home, ".docker/desktop/docker.sock"),
+			filepath.Join(home, ".colima/default/docker.sock"),
+			filepath.Join(home, ".rd/docker.sock"),
+		)
+	}
+	return append(paths, podman...)
+}
+
+// New creates a client for a unix:// or tcp:// host. TLS endpoints are not
+// supported.
+func New(host string) (*Client, error) {
+	u, err := url.Parse(host)
+	if err != nil {
+		return nil, fmt.Errorf("invalid host %q: %w", host, err)
+	}
+
+	transport := &http.Transport{DisableCompression: true}
+	switch u.Scheme {
+	case "unix":
+		path := u.Path
+		if path == "" {
+			path = u.Opaque
+		}
+		transport.DialContext = func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, "unix", path)
+		}
+		return &Client{http: &http.Client{Transport: transport, Timeout: Timeout}, base: "http://docker"}, nil
+	case "tcp", "http":
+		return &Client{http: &http.Client{Transport: transport, Timeout: Timeout}, base: "http://" + u.Host}, nil
+	}
+	return nil, fmt.Errorf("unsupported host %q", host)
+}
+
+// Error is a non-2xx reply from the API
+type Error struct {
+	Status  int
+	Message string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("engine API: %d %s", e.Status, e.Message)
+}
+
+// IsNotFound reports whether err is a 404 reply
+func IsNotFound(err error) bool {
+	var apiErr *Error
+	return errors.As(err, &apiErr) && apiErr.Status == http.StatusNotFound
+}
+
+func (c *Client) get(ctx context.Context, path string, query url.Values, v any) error {
+	u := c.base + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		var msg struct{ Message string }
+		if json.Unmarshal(body, &msg) != nil || msg.Message == "" {
+			msg.Message = strings.TrimSpace(string(body))
+		}
+		return &Error{Status: resp.StatusCode, Message: msg.Message}
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// Inspect returns the full state of a container by ID or name
+func (c *Client) Inspect(ctx context.Context, id string) (*Container, error) {
+	var ctr Container
+	if err := c.get(ctx, "/containers/"+url.PathEscape(id)+"/json", nil, &ctr); err != nil {
+		return nil, err
+	}
+	return &ctr, nil
+}
+
+// List returns running containers matching filters such as
+// {"publish": {"8080"}}
+func (c *Client) List(ctx context.Context, filters map[string][]string) ([]Summary, error) {
+	query := url.Values{}
+	if len(filters) > 0 {
+		data, err := json.Marshal(filters)
+		if err != nil {
+			return nil, err
+		}
+		query.Set("filters", string(data))
+	}
+	var list []Summary
+	if err := c.get(ctx, "/containers/json", query, &list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// ContainerByIP finds the running container with ip on any of its networks
+func (c *Client) ContainerByIP(ctx context.Context, ip string) (*Summary, error) {
+	list, err := c.List(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	for i := range list {
+		for _, n := range list[i].NetworkSettings.Networks {
+			if n.IPAddress == ip || n.GlobalIPv6Address == ip {
+				return &list[i], nil
+			}
+		}
+	}
+	return nil, nil
+}
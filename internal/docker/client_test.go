@@ -0,0 +1,152 @@
+package docker
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+const inspectJSON = `{
+  "Id": "3f2a9c",
+  "Name": "/shop-api-1",
+  "Image": "sha256:abc",
+  "RestartCount": 2,
+  "Config": {
+    "Image": "ghcr.io/acme/api:1.4",
+    "Labels": {"com.docker.compose.project": "shop", "com.docker.compose.service": "api"},
+    "Healthcheck": {"Test": ["CMD", "curl", "-f", "http://localhost/health"]}
+  },
+  "State": {"Status": "running", "Running": true, "Health": {"Status": "unhealthy", "FailingStreak": 4}},
+  "HostConfig": {"RestartPolicy": {"Name": "on-failure", "MaximumRetryCount": 5}},
+  "NetworkSettings": {
+    "Ports": {"80/tcp": [{"HostIp": "0.0.0.0", "HostPort": "8080"}, {"HostIp": "::", "HostPort": "8080"}], "9000/tcp": null},
+    "Networks": {"shop_default": {"IPAddress": "172.18.0.3"}, "monitoring": {"IPAddress": "172.20.0.7"}}
+  }
+}`
+
+const listJSON = `[
+  {"Id": "3f2a9c", "Names": ["/shop-api-1"], "Image": "ghcr.io/acme/api:1.4",
+   "Labels": {"com.docker.compose.project": "shop", "com.docker.compose.service": "api"},
+   "Ports": [{"IP": "0.0.0.0", "PrivatePort": 80, "PublicPort": 8080, "Type": "tcp"}, {"PrivatePort": 9000, "Type": "tcp"}],
+   "NetworkSettings": {"Networks": {"shop_default": {"IPAddress": "172.18.0.3"}, "monitoring": {"IPAddress": "172.20.0.7"}}}},
+  {"Id": "77b0e1", "Names": ["/redis"], "Image": "redis:7",
+   "NetworkSettings": {"Networks": {"bridge": {"IPAddress": "172.17.0.2"}}}}
+]`
+
+// fakeEngine serves canned Engine API replies on a unix socket
+func fakeEngine(t *testing.T) (*Client, *[]string) {
+	t.Helper()
+	sock := filepath.Join(t.TempDir(), "docker.sock")
+	ln, err := net.Listen("unix", sock)
+	if err != nil {
+		t.Skipf("unix sockets unavailable: %v", err)
+	}
+
+	var filters []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /containers/{id}/json", func(w http.ResponseWriter, r *http.Request) {
+		if r.PathValue("id") != "3f2a9c" && r.PathValue("id") != "shop-api-1" {
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(map[string]string{"message": "No such container: " + r.PathValue("id")})
+			return
+		}
+		w.Write([]byte(inspectJSON))
+	})
+	mux.HandleFunc("GET /containers/json", func(w http.ResponseWriter, r *http.Request) {
+		filters = append(filters, r.URL.Query().Get("filters"))
+		if r.URL.Query().Get("filters") != "" {
+			var f map[string][]string
+			json.Unmarshal([]byte(r.URL.Query().Get("filters")), &f)
+			if !slices.Equal(f["publish"], []string{"8080"}) {
+				w.Write([]byte("[]"))
+				return
+			}
+		}
+		w.Write([]byte(listJSON))
+	})
+
+	srv := httptest.NewUnstartedServer(mux)
+	srv.Listener = ln
+	srv.Start()
+	t.Cleanup(srv.Close)
+
+	c, err := New("unix://" + sock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c, &filters
+}
+
+func TestInspect(t *testing.T) {
+	c, _ := fakeEngine(t)
+	ctr, err := c.Inspect(context.Background(), "3f2a9c")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ctr.ContainerName() != "shop-api-1" || ctr.Config.Image != "ghcr.io/acme/api:1.4" || ctr.RestartCount != 2 {
+		t.Errorf("unexpected container: %+v", ctr)
+	}
+	if ctr.Config.Labels[LabelComposeService] != "api" || ctr.HostConfig.RestartPolicy.MaximumRetryCount != 5 {
+		t.Errorf("labels or restart policy not decoded: %+v", ctr)
+	}
+	if !ctr.HasHealthcheck() || ctr.State.Health.Status != "unhealthy" || ctr.State.Health.FailingStreak != 4 {
+		t.Errorf("health not decoded: %+v", ctr.State.Health)
+	}
+	if got := ctr.NetworkNames(); !slices.Equal(got, []string{"monitoring (172.20.0.7)", "shop_default (172.18.0.3)"}) {
+		t.Errorf("NetworkNames = %q", got)
+	}
+	if got := ctr.PortBindings(); !slices.Equal(got, []string{"0.0.0.0:8080->80/tcp", "[::]:8080->80/tcp"}) {
+		t.Errorf("PortBindings = %q", got)
+	}
+
+	_, err = c.Inspect(context.Background(), "missing")
+	if !IsNotFound(err) {
+		t.Fatalf("expected not found, got %v", err)
+	}
+	if err.Error() != "engine API: 404 No such container: missing" {
+		t.Errorf("error = %q", err)
+	}
+}
+
+func TestListAndContainerByIP(t *testing.T) {
+	c, filters := fakeEngine(t)
+	ctx := context.Background()
+
+	list, err := c.List(ctx, map[string][]string{"publish": {"8080"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 2 || list[0].Name() != "shop-api-1" {
+		t.Fatalf("unexpected list: %+v", list)
+	}
+	if got := list[0].PortsString(); got != "0.0.0.0:8080->80/tcp, 9000/tcp" {
+		t.Errorf("PortsString = %q", got)
+	}
+	if (*filters)[0] != `{"publish":["8080"]}` {
+		t.Errorf("filters = %q", (*filters)[0])
+	}
+
+	// Containers on user-defined networks are found, not only on bridge
+	s, err := c.ContainerByIP(ctx, "172.20.0.7")
+	if err != nil || s == nil || s.Name() != "shop-api-1" {
+		t.Fatalf("ContainerByIP = %+v, %v", s, err)
+	}
+	if s, err := c.ContainerByIP(ctx, "10.0.0.1"); err != nil || s != nil {
+		t.Fatalf("ContainerByIP(unknown) = %+v, %v", s, err)
+	}
+}
+
+func TestNewHosts(t *testing.T) {
+	c, err := New("tcp://127.0.0.1:2375")
+	if err != nil || c.base != "http://127.0.0.1:2375" {
+		t.Fatalf("tcp host: %+v, %v", c, err)
+	}
+	if _, err := New("ssh://user@host"); err == nil {
+		t.Fatal("ssh host accepted")
+	}
+}
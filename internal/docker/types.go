@@ -0,0 +1,161 @@
+package docker
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Compose labels set by docker compose and podman-compose
+const (
+	LabelComposeProject = "com.docker.compose.project"
+	LabelComposeService = "com.docker.compose.service"
+)
+
+// Container is the subset of GET /containers/{id}/json that witr reports
+type Container struct {
+	ID           string `json:"Id"`
+	Name         string
+	Image        string // image ID
+	RestartCount int
+	Config       struct {
+		Image       string
+		Labels      map[string]string
+		Healthcheck *struct {
+			Test []string
+		}
+	}
+	State struct {
+		Status     string
+		Running    bool
+		Restarting bool
+		ExitCode   int
+		StartedAt  string
+		Health     *Health
+	}
+	HostConfig struct {
+		RestartPolicy struct {
+			Name              string
+			MaximumRetryCount int
+		}
+	}
+	NetworkSettings struct {
+		Ports    map[string][]PortBinding
+		Networks map[string]Network
+	}
+}
+
+// Health is the healthcheck state of a container
+type Health struct {
+	Status        string // starting, healthy, unhealthy
+	FailingStreak int
+	Log           []struct {
+		ExitCode int
+		Output   string
+	}
+}
+
+// PortBinding is a host address a container port is published on
+type PortBinding struct {
+	HostIP   string `json:"HostIp"`
+	HostPort string
+}
+
+// Network is a container's endpoint on one network
+type Network struct {
+	IPAddress         string
+	GlobalIPv6Address string
+}
+
+// Summary is one entry of GET /containers/json
+type Summary struct {
+	ID              string `json:"Id"`
+	Names           []string
+	Image           string
+	Labels          map[string]string
+	State           string
+	Status          string
+	Ports           []Port
+	NetworkSettings struct {
+		Networks map[string]Network
+	}
+}
+
+// Port is a published or exposed port in a container list entry
+type Port struct {
+	IP          string
+	PrivatePort int
+	PublicPort  int
+	Type        string
+}
+
+// ContainerName returns the name without the leading slash
+func (c *Container) ContainerName() string {
+	return strings.TrimPrefix(c.Name, "/")
+}
+
+// HasHealthcheck reports whether the image or run options define a
+// healthcheck that is not disabled with NONE
+func (c *Container) HasHealthcheck() bool {
+	if c.State.Health != nil {
+		return true
+	}
+	hc := c.Config.Healthcheck
+	return hc != nil && len(hc.Test) > 0 && hc.Test[0] != "NONE"
+}
+
+// NetworkNames lists the networks the container is attached to, with addresses
+func (c *Container) NetworkNames() []string {
+	var out []string
+	for name, n := range c.NetworkSettings.Networks {
+		if n.IPAddress != "" {
+			name += " (" + n.IPAddress + ")"
+		}
+		out = append(out, name)
+	}
+	slices.Sort(out)
+	return out
+}
+
+// PortBindings renders published ports as docker ps does: "0.0.0.0:8080->80/tcp"
+func (c *Container) PortBindings() []string {
+	var out []string
+	for port, bindings := range c.NetworkSettings.Ports {
+		for _, b := range bindings {
+			host := b.HostIP
+			if strings.Contains(host, ":") {
+				host = "[" + host + "]"
+			}
+			out = append(out, host+":"+b.HostPort+"->"+port)
+		}
+	}
+	slices.Sort(out)
+	return out
+}
+
+// Name returns the primary name of a list entry without the leading slash
+func (s *Summary) Name() string {
+	if len(s.Names) == 0 {
+		return ""
+	}
+	return strings.TrimPrefix(s.Names[0], "/")
+}
+
+// PortsString renders the ports of a list entry as docker ps does
+func (s *Summary) PortsString() string {
+	var out []string
+	for _, p := range s.Ports {
+		switch {
+		case p.PublicPort != 0:
+			ip := p.IP
+			if strings.Contains(ip, ":") {
+				ip = "[" + ip + "]"
+			}
+			out = append(out, fmt.Sprintf("%s:%d->%d/%s", ip, p.PublicPort, p.PrivatePort, p.Type))
+		default:
+			out = append(out, strconv.Itoa(p.PrivatePort)+"/"+p.Type)
+		}
+	}
+	return strings.Join(out, ", ")
+}
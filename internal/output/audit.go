@@ -0,0 +1,51 @@
+package output
+
+import (
+	"io"
+	"strings"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+// RenderAudit prints the result of a hidden-process scan
+func RenderAudit(w io.Writer, a *model.AuditResult, colorEnabled bool) {
+	p := NewPrinter(w)
+
+	labelColor, okColor, badColor, bold, reset := ansiString(""), ansiString(""), ansiString(""), ansiString(""), ansiString("")
+	if colorEnabled {
+		labelColor, okColor, badColor, bold, reset = ColorBlue, ColorGreen, ColorRed, ColorBold, ColorReset
+	}
+
+	if a.Skipped != "" {
+		p.Printf("%sAudit%s       : skipped (%s)\n", labelColor, reset, a.Skipped)
+		return
+	}
+
+	p.Printf("%sAudit%s       : probed PIDs 1-%d, %d listed in /proc\n", labelColor, reset, a.PIDMax, a.Listed)
+
+	if len(a.Hidden) == 0 {
+		p.Printf("%sHidden%s      : %sNo hidden processes found.%s\n", labelColor, reset, okColor, reset)
+		return
+	}
+
+	p.Printf("%sHidden%s      : %s%d process(es) alive but missing from the /proc listing%s\n", labelColor, reset, badColor, len(a.Hidden), reset)
+	for _, h := range a.Hidden {
+		name := h.Command
+		if name == "" {
+			name = "unknown"
+		}
+		p.Printf("  • %s (%spid %d%s), found via %s\n", name, bold, h.PID, reset, strings.Join(h.Methods, ", "))
+		if h.Cmdline != "" {
+			p.Printf("      Command : %s\n", h.Cmdline)
+		}
+		if h.Exe != "" {
+			p.Printf("      Exe     : %s\n", h.Exe)
+		}
+		if h.User != "" {
+			p.Printf("      User    : %s\n", h.User)
+		}
+		if h.PPID > 0 {
+			p.Printf("      Parent  : pid %d\n", h.PPID)
+		}
+	}
+}
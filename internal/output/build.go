@@ -0,0 +1,50 @@
+package output
+
+import (
+	"strings"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+// renderBuild identifies the running binary: Go module and VCS stamps when
+// embedded, otherwise the ELF build-id.
+func renderBuild(out Printer, b *model.BuildInfo, colorEnabled bool) {
+	labelColor, dim, reset := ansiString(""), ansiString(""), ansiString("")
+	if colorEnabled {
+		labelColor, dim, reset = ColorCyan, ColorBold, ColorReset
+	}
+
+	if b.GoVersion == "" {
+		out.Printf("%sBuild%s       : build-id %s\n", labelColor, reset, b.BuildID)
+		return
+	}
+
+	module := b.Module
+	if module == "" {
+		module = "unknown module"
+	}
+	head := module
+	if b.Version != "" {
+		head += " " + b.Version
+	}
+	out.Printf("%sBuild%s       : %s %s(%s)%s\n", labelColor, reset, head, dim, b.GoVersion, reset)
+
+	if b.Revision != "" {
+		var parts []string
+		rev := b.Revision
+		if len(rev) > 12 {
+			rev = rev[:12]
+		}
+		parts = append(parts, "revision "+rev)
+		if !b.RevisionTime.IsZero() {
+			parts = append(parts, "committed "+b.RevisionTime.UTC().Format("2006-01-02 15:04 UTC"))
+		}
+		if b.Modified {
+			parts = append(parts, "built with uncommitted changes")
+		}
+		out.Printf("              %s\n", strings.Join(parts, ", "))
+	}
+	if b.BuildID != "" {
+		out.Printf("              %sbuild-id %s%s\n", dim, b.BuildID, reset)
+	}
+}
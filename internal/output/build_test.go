@@ -0,0 +1,34 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+func TestRenderBuild(t *testing.T) {
+	var buf bytes.Buffer
+	renderBuild(NewPrinter(&buf), &model.BuildInfo{
+		GoVersion:    "go1.22.1",
+		Module:       "github.com/acme/api",
+		Version:      "v1.4.0",
+		Revision:     "0123456789abcdef0123",
+		RevisionTime: time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC),
+		Modified:     true,
+	}, false)
+
+	want := "Build       : github.com/acme/api v1.4.0 (go1.22.1)\n" +
+		"              revision 0123456789ab, committed 2026-03-01 09:30 UTC, built with uncommitted changes\n"
+	if buf.String() != want {
+		t.Fatalf("got:\n%s\nwant:\n%s", buf.String(), want)
+	}
+
+	buf.Reset()
+	renderBuild(NewPrinter(&buf), &model.BuildInfo{BuildID: "e3103c60"}, false)
+	if !strings.Contains(buf.String(), "Build       : build-id e3103c60") {
+		t.Fatalf("expected build-id line, got %q", buf.String())
+	}
+}
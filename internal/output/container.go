@@ -0,0 +1,66 @@
+package output
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+// renderContainer prints what the engine reports about a container under the
+// Container line: image, restart policy, health, networks and published ports
+func renderContainer(out Printer, c *model.ContainerInfo) {
+	row := func(label, value string) {
+		if value != "" {
+			out.Printf("              %s%s : %s\n", label, strings.Repeat(" ", 12-len(label)), value)
+		}
+	}
+
+	row("Image", c.Image)
+
+	restart := c.RestartPolicy
+	if c.RestartCount > 0 {
+		if restart == "" {
+			restart = "no"
+		}
+		restart += " (restarted " + strconv.Itoa(c.RestartCount) + " times)"
+	}
+	if c.Restarting {
+		restart += ", restarting now"
+	}
+	row("Restart", strings.TrimPrefix(restart, ", "))
+
+	switch {
+	case c.Health != "":
+		health := c.Health
+		if c.FailingStreak > 0 {
+			health += " (" + strconv.Itoa(c.FailingStreak) + " failed checks in a row)"
+		}
+		row("Health", health)
+		if c.Health == "unhealthy" {
+			row("Last Check", c.HealthOutput)
+		}
+	case !c.Healthcheck:
+		row("Health", "no healthcheck")
+	}
+
+	row("Networks", strings.Join(c.Networks, ", "))
+	row("Ports", strings.Join(c.Ports, ", "))
+}
+
+// renderKubernetes prints the pod details under the Container line: image,
+// QoS class, restarts within the pod and the pod UID
+func renderKubernetes(out Printer, k *model.KubernetesInfo) {
+	row := func(label, value string) {
+		if value != "" {
+			out.Printf("              %s%s : %s\n", label, strings.Repeat(" ", 12-len(label)), value)
+		}
+	}
+
+	row("Image", k.Image)
+	row("QoS Class", k.QoSClass)
+	if k.Restarts > 0 {
+		row("Restarts", strconv.Itoa(k.Restarts))
+	}
+	row("Pod UID", k.PodUID)
+}
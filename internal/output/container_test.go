@@ -0,0 +1,53 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+func TestRenderContainer(t *testing.T) {
+	var buf bytes.Buffer
+	renderContainer(NewPrinter(&buf), &model.ContainerInfo{
+		Image:         "ghcr.io/acme/api:1.4",
+		RestartPolicy: "on-failure:5",
+		RestartCount:  3,
+		Healthcheck:   true,
+		Health:        "unhealthy",
+		FailingStreak: 4,
+		HealthOutput:  "curl: (7) Failed to connect",
+		Networks:      []string{"shop_default (172.18.0.3)"},
+		Ports:         []string{"0.0.0.0:8080->80/tcp"},
+	})
+
+	want := `              Image        : ghcr.io/acme/api:1.4
+              Restart      : on-failure:5 (restarted 3 times)
+              Health       : unhealthy (4 failed checks in a row)
+              Last Check   : curl: (7) Failed to connect
+              Networks     : shop_default (172.18.0.3)
+              Ports        : 0.0.0.0:8080->80/tcp
+`
+	if buf.String() != want {
+		t.Fatalf("got:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestRenderKubernetes(t *testing.T) {
+	var buf bytes.Buffer
+	renderKubernetes(NewPrinter(&buf), &model.KubernetesInfo{
+		Image:    "ghcr.io/acme/api:1.4",
+		QoSClass: "Burstable",
+		Restarts: 2,
+		PodUID:   "1b4e28ba-2fa1-11d2-883f-0016d3cca427",
+	})
+
+	want := `              Image        : ghcr.io/acme/api:1.4
+              QoS Class    : Burstable
+              Restarts     : 2
+              Pod UID      : 1b4e28ba-2fa1-11d2-883f-0016d3cca427
+`
+	if buf.String() != want {
+		t.Fatalf("got:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
@@ -0,0 +1,43 @@
+package output
+
+import "strings"
+
+type detailRow struct{ key, label string }
+
+// cronRows are the Source.Details keys of a cron job, in display order
+var cronRows = []detailRow{
+	{"schedule", "Schedule"},
+	{"next_run", "Next Run"},
+	{"cron_user", "User"},
+	{"command", "Command"},
+	{"script", "Script"},
+}
+
+// atRows are the Source.Details keys of an at or batch job
+var atRows = []detailRow{
+	{"at_job", "Job"},
+	{"queue", "Queue"},
+	{"queued_by", "Queued By"},
+	{"queued_at", "Queued At"},
+	{"run_at", "Run At"},
+	{"at_dir", "Directory"},
+	{"command", "Command"},
+}
+
+// renderJobRows prints the entry behind a cron or at job below its job file.
+// Multi-line values continue under the first line.
+func renderJobRows(out Printer, rows []detailRow, d map[string]string) {
+	for _, row := range rows {
+		value := d[row.key]
+		if value == "" {
+			continue
+		}
+		for i, line := range strings.Split(value, "\n") {
+			prefix := "              " + row.label + strings.Repeat(" ", 12-len(row.label)) + " : "
+			if i > 0 {
+				prefix = strings.Repeat(" ", 29)
+			}
+			out.Printf("%s%s\n", prefix, line)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestRenderJobRows(t *testing.T) {
+	var buf bytes.Buffer
+	renderJobRows(NewPrinter(&buf), atRows, map[string]string{
+		"at_job":    "11",
+		"queue":     "running",
+		"queued_by": "alice",
+		"queued_at": "2026-10-19 09:41",
+		"command":   "./build-report.sh --month 10\nmail -s done alice < out.txt",
+	})
+
+	want := `              Job          : 11
+              Queue        : running
+              Queued By    : alice
+              Queued At    : 2026-10-19 09:41
+              Command      : ./build-report.sh --month 10
+                             mail -s done alice < out.txt
+`
+	if buf.String() != want {
+		t.Fatalf("got:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
@@ -44,3 +44,59 @@ func RenderEnvOnly(w io.Writer, r model.Result, colorEnabled bool) {
 		p.Printf("%sEnvironment%s : %sNo environment variables found.%s\n", colorBlueEnv, colorResetEnv, colorRedEnv, colorResetEnv)
 	}
 }
+
+// envOriginMarks prefixes each variable in the diff view by origin
+var envOriginMarks = map[string]string{
+	"added":        "+",
+	"overridden":   "~",
+	"unit-defined": "U",
+	"inherited":    " ",
+}
+
+// RenderEnvDiff prints the variables a process added or changed relative to its
+// parent and systemd unit; inherited variables are only counted.
+func RenderEnvDiff(w io.Writer, r model.Result, d *model.EnvDiff, colorEnabled bool) {
+	p := NewPrinter(w)
+
+	blue, green, yellow, magenta, bold, reset := ansiString(""), ansiString(""), ansiString(""), ansiString(""), ansiString(""), ansiString("")
+	if colorEnabled {
+		blue, green, yellow, magenta, bold, reset = ColorBlue, ColorGreen, ColorDimYellow, ColorMagenta, ColorBold, ColorReset
+	}
+
+	p.Printf("%sProcess%s     : %s%s%s (%spid %d%s)\n", blue, reset, green, r.Process.Command, reset, bold, r.Process.PID, reset)
+	parent := d.ParentCommand
+	if parent == "" {
+		parent = "unknown"
+	}
+	p.Printf("%sParent%s      : %s (%spid %d%s)\n", blue, reset, parent, bold, d.ParentPID, reset)
+	if d.Unit != "" {
+		p.Printf("%sUnit%s        : %s\n", blue, reset, d.Unit)
+	}
+
+	counts := map[string]int{}
+	for _, v := range d.Vars {
+		counts[v.Origin]++
+	}
+	p.Printf("%sEnvironment%s : %d added, %d overridden, %d unit-defined, %d inherited\n", blue, reset,
+		counts["added"], counts["overridden"], counts["unit-defined"], counts["inherited"])
+
+	for _, v := range d.Vars {
+		color := green
+		switch v.Origin {
+		case "inherited":
+			continue
+		case "overridden":
+			color = yellow
+		case "unit-defined":
+			color = magenta
+		}
+		p.Printf("  %s%s%s %s=%s", color, ansiString(envOriginMarks[v.Origin]), reset, v.Key, v.Value)
+		switch {
+		case v.Origin == "overridden":
+			p.Printf("  %s(parent: %s)%s", bold, v.ParentValue, reset)
+		case v.DefinedIn != "":
+			p.Printf("  %s(%s)%s", bold, v.DefinedIn, reset)
+		}
+		p.Println()
+	}
+}
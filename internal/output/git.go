@@ -0,0 +1,54 @@
+package output
+
+import (
+	"strings"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+// renderGit prints the checkout the process runs from: branch or detached
+// commit, worktree kind, upstream remote and whether the binary predates HEAD.
+func renderGit(out Printer, proc model.Process, colorEnabled bool) {
+	labelColor, reset := ansiString(""), ansiString("")
+	if colorEnabled {
+		labelColor, reset = ColorCyan, ColorReset
+	}
+
+	g := proc.Git
+	ref := proc.GitBranch
+	if g != nil && g.Detached && len(g.Commit) >= 7 {
+		ref = "detached at " + g.Commit[:7]
+	}
+	if ref != "" {
+		out.Printf("%sGit Repo%s    : %s (%s)\n", labelColor, reset, proc.GitRepo, ref)
+	} else {
+		out.Printf("%sGit Repo%s    : %s\n", labelColor, reset, proc.GitRepo)
+	}
+	if g == nil {
+		return
+	}
+
+	var notes []string
+	if !g.Detached && len(g.Commit) >= 7 {
+		notes = append(notes, "commit "+g.Commit[:7])
+	}
+	switch {
+	case g.Worktree:
+		notes = append(notes, "linked worktree")
+	case g.Submodule:
+		notes = append(notes, "submodule")
+	}
+	if g.BinaryInRepo && !g.HeadTime.IsZero() {
+		if g.BinaryNewer {
+			notes = append(notes, "binary built after HEAD")
+		} else {
+			notes = append(notes, "binary older than HEAD")
+		}
+	}
+	if len(notes) > 0 {
+		out.Printf("              %s\n", strings.Join(notes, ", "))
+	}
+	if g.RemoteURL != "" {
+		out.Printf("              remote %s %s\n", g.Remote, g.RemoteURL)
+	}
+}
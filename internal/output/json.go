@@ -34,11 +34,13 @@ func ToShortJSON(r model.Result) (string, error) {
 func ToTreeJSON(r model.Result) (string, error) {
 	type treeResult struct {
 		Ancestry []shortProcess
-		Children []shortProcess `json:",omitempty"`
+		Children []shortProcess     `json:",omitempty"`
+		Tree     *model.ProcessTree `json:",omitempty"`
 	}
 
 	res := treeResult{
 		Ancestry: make([]shortProcess, len(r.Ancestry)),
+		Tree:     r.Tree,
 	}
 
 	for i, p := range r.Ancestry {
@@ -126,3 +128,33 @@ func ToEnvJSON(r model.Result) (string, error) {
 	}
 	return string(data), nil
 }
+
+func ToAuditJSON(a *model.AuditResult) (string, error) {
+	data, err := json.MarshalIndent(a, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+func ToEnvDiffJSON(r model.Result, d *model.EnvDiff) (string, error) {
+	type envDiffResult struct {
+		PID     int
+		Process string
+		Command string
+		*model.EnvDiff
+	}
+
+	res := envDiffResult{
+		PID:     r.Process.PID,
+		Process: r.Process.Command,
+		Command: r.Process.Cmdline,
+		EnvDiff: d,
+	}
+
+	data, err := json.MarshalIndent(res, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
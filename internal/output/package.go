@@ -0,0 +1,25 @@
+package output
+
+import "github.com/pranshuparmar/witr/pkg/model"
+
+// renderPackage names the package that installed the executable
+func renderPackage(out Printer, p *model.PackageInfo, colorEnabled bool) {
+	labelColor, dim, reset := ansiString(""), ansiString(""), ansiString("")
+	if colorEnabled {
+		labelColor, dim, reset = ColorCyan, ColorBold, ColorReset
+	}
+
+	if p.Manual {
+		out.Printf("%sPackage%s     : none %s(manual install under %s)%s\n", labelColor, reset, dim, p.Prefix, reset)
+		return
+	}
+	name := p.Name
+	if p.Version != "" {
+		name += " " + p.Version
+	}
+	manager := p.Manager
+	if p.Integrity != "" {
+		manager += ", " + p.Integrity
+	}
+	out.Printf("%sPackage%s     : %s %s(%s)%s\n", labelColor, reset, name, dim, manager, reset)
+}
@@ -0,0 +1,33 @@
+package output
+
+import "github.com/pranshuparmar/witr/pkg/model"
+
+// renderZombie explains which parent is failing to reap a defunct process
+func renderZombie(out Printer, z *model.ZombieInfo, colorEnabled bool) {
+	labelColor, reset := ansiString(""), ansiString("")
+	if colorEnabled {
+		labelColor, reset = ColorRed, ColorReset
+	}
+
+	out.Printf("\n%sZombie%s      : parent %s (pid %d) is not reaping it\n", labelColor, reset, z.ParentCommand, z.ParentPID)
+	out.Printf("              Parent source: %s\n", sourceLabel(z.ParentSource))
+	if z.ZombieCount > 1 {
+		out.Printf("              Parent holds %d zombie children", z.ZombieCount)
+	} else {
+		out.Printf("              Parent holds 1 zombie child")
+	}
+	if !z.OldestStartedAt.IsZero() {
+		out.Printf("; oldest started %s", formatAge(z.OldestStartedAt))
+	}
+	out.Println()
+}
+
+// renderOrphan explains how a process ended up under init or a subreaper
+func renderOrphan(out Printer, o *model.OrphanInfo, colorEnabled bool) {
+	labelColor, reset := ansiString(""), ansiString("")
+	if colorEnabled {
+		labelColor, reset = ColorDimYellow, ColorReset
+	}
+
+	out.Printf("\n%sReparented%s  : %s\n", labelColor, reset, o.Explanation)
+}
@@ -0,0 +1,72 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+func TestRenderStandardZombie(t *testing.T) {
+	res := model.Result{
+		Ancestry: []model.Process{
+			{PID: 1, Command: "systemd"},
+			{PID: 200, Command: "worker", StartedAt: time.Now()},
+			{PID: 201, Command: "child", Health: "zombie", StartedAt: time.Now()},
+		},
+		Source: model.Source{Type: model.SourceSystemd, Name: "systemd"},
+		Zombie: &model.ZombieInfo{
+			ParentPID:       200,
+			ParentCommand:   "worker",
+			ParentSource:    model.Source{Type: model.SourceSystemd, Name: "systemd"},
+			ZombieCount:     3,
+			OldestStartedAt: time.Now().Add(-3 * time.Hour),
+		},
+	}
+
+	var buf bytes.Buffer
+	RenderStandard(&buf, res, false, false)
+	out := buf.String()
+
+	expected := []string{
+		"Process     : child (pid 201) [zombie]",
+		"Zombie      : parent worker (pid 200) is not reaping it",
+		"Parent source: systemd",
+		"Parent holds 3 zombie children; oldest started 3 hours ago",
+	}
+	for _, want := range expected {
+		if !strings.Contains(out, want) {
+			t.Errorf("RenderStandard output missing %q\nGot:\n%s", want, out)
+		}
+	}
+}
+
+func TestRenderStandardOrphan(t *testing.T) {
+	res := model.Result{
+		Ancestry: []model.Process{
+			{PID: 1200, Command: "systemd"},
+			{PID: 4242, Command: "node", StartedAt: time.Now()},
+		},
+		Source: model.Source{Type: model.SourceUnknown},
+		Orphan: &model.OrphanInfo{
+			ReaperPID:     1200,
+			ReaperCommand: "systemd --user",
+			Subreaper:     true,
+			LeaderPID:     4000,
+			Explanation:   "Its original parent and session/group leader (pid 4000) exited; it was reparented to systemd --user (pid 1200)",
+		},
+	}
+
+	var buf bytes.Buffer
+	RenderStandard(&buf, res, false, false)
+	out := buf.String()
+
+	if !strings.Contains(out, "Process     : node (pid 4242) {orphaned}") {
+		t.Errorf("expected orphaned marker, got:\n%s", out)
+	}
+	if !strings.Contains(out, "Reparented  : Its original parent") {
+		t.Errorf("expected reparenting explanation, got:\n%s", out)
+	}
+}
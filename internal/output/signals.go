@@ -0,0 +1,52 @@
+package output
+
+import (
+	"strings"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+// renderSignalNotes prints the consequences of termination signal dispositions
+func renderSignalNotes(out Printer, si *model.SignalInfo, colorEnabled bool) {
+	if len(si.Explanations) == 0 {
+		return
+	}
+	labelColor, reset := ansiString(""), ansiString("")
+	if colorEnabled {
+		labelColor, reset = ColorDimYellow, ColorReset
+	}
+
+	out.Printf("\n%sSignals%s     : %s\n", labelColor, reset, si.Explanations[0])
+	for _, e := range si.Explanations[1:] {
+		out.Printf("              %s\n", e)
+	}
+}
+
+// renderSignalMasks prints every decoded signal disposition (verbose mode)
+func renderSignalMasks(out Printer, si *model.SignalInfo, colorEnabled bool) {
+	lists := []struct {
+		label string
+		names []string
+	}{
+		{"Ignored", si.Ignored},
+		{"Caught", si.Caught},
+		{"Blocked", si.Blocked},
+		{"Pending", si.Pending},
+	}
+
+	printed := false
+	for _, l := range lists {
+		if len(l.names) == 0 {
+			continue
+		}
+		if !printed {
+			if colorEnabled {
+				out.Printf("\n%sSignal Dispositions%s:\n", ColorGreen, ColorReset)
+			} else {
+				out.Printf("\nSignal Dispositions:\n")
+			}
+			printed = true
+		}
+		out.Printf("  %-7s : %s\n", l.label, strings.Join(l.names, " "))
+	}
+}
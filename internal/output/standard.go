@@ -29,6 +29,65 @@ func formatDetailLabel(key string) string {
 	return "              " + key
 }
 
+// formatAge renders how long ago t was, e.g. "2 days ago" or "just now"
+func formatAge(t time.Time) string {
+	dur := time.Since(t)
+	switch {
+	case dur.Hours() >= 48:
+		days := int(dur.Hours()) / 24
+		return fmt.Sprintf("%d days ago", days)
+	case dur.Hours() >= 24:
+		return "1 day ago"
+	case dur.Hours() >= 2:
+		hours := int(dur.Hours())
+		return fmt.Sprintf("%d hours ago", hours)
+	case dur.Minutes() >= 60:
+		return "1 hour ago"
+	default:
+		mins := int(dur.Minutes())
+		if mins > 0 {
+			return fmt.Sprintf("%d min ago", mins)
+		}
+		return "just now"
+	}
+}
+
+// describeSession explains the job control mode of a process, e.g.
+// "background job on pts/3 (sid 812, pgid 1290)"
+func describeSession(pid int, s model.SessionInfo) string {
+	var desc string
+	switch s.Mode {
+	case "foreground":
+		desc = "foreground job on " + s.TTY
+	case "background":
+		desc = "background job on " + s.TTY
+	case "detached":
+		if s.TTY != "" {
+			desc = "detached from " + s.TTY + " after its login session ended (nohup/disown)"
+		} else {
+			desc = "detached from its terminal via setsid/nohup/disown"
+		}
+	case "daemon":
+		if s.SID == pid {
+			desc = "daemonized session leader with no controlling terminal"
+		} else {
+			desc = "member of a daemon session with no controlling terminal"
+		}
+	default:
+		return ""
+	}
+	return fmt.Sprintf("%s (sid %d, pgid %d)", desc, s.SID, s.PGID)
+}
+
+// sourceLabel formats a source as "name (type)", or just the type when they match
+func sourceLabel(src model.Source) string {
+	label := string(src.Type)
+	if src.Name != "" && src.Name != label {
+		return src.Name + " (" + label + ")"
+	}
+	return label
+}
+
 func RenderWarnings(w io.Writer, r model.Result, colorEnabled bool) {
 	out := NewPrinter(w)
 
@@ -115,13 +174,13 @@ func RenderStandard(w io.Writer, r model.Result, colorEnabled bool, verbose bool
 			out.Printf(" [%s]", health)
 		}
 	}
-	// Forked status: only display if forked
-	if proc.Forked == "forked" {
+	// Orphan status: only display if reparented
+	if r.Orphan != nil {
 		forkColor := ColorDimYellow
 		if colorEnabled {
-			out.Printf(" %s{forked}%s", forkColor, ColorReset)
+			out.Printf(" %s{orphaned}%s", forkColor, ColorReset)
 		} else {
-			out.Printf(" {forked}")
+			out.Printf(" {orphaned}")
 		}
 	}
 	out.Println("")
@@ -140,6 +199,12 @@ func RenderStandard(w io.Writer, r model.Result, colorEnabled bool, verbose bool
 		} else {
 			out.Printf("Container   : %s\n", proc.Container)
 		}
+		if proc.ContainerInfo != nil {
+			renderContainer(out, proc.ContainerInfo)
+		}
+		if proc.Kubernetes != nil {
+			renderKubernetes(out, proc.Kubernetes)
+		}
 	}
 	// Service
 	if proc.Service != "" {
@@ -165,34 +230,21 @@ func RenderStandard(w io.Writer, r model.Result, colorEnabled bool, verbose bool
 	}
 	// Format as: 2 days ago (Mon 2025-02-02 11:42:10 +0530)
 	startedAt := proc.StartedAt
-	now := time.Now()
-	dur := now.Sub(startedAt)
-	var rel string
-	switch {
-	case dur.Hours() >= 48:
-		days := int(dur.Hours()) / 24
-		rel = fmt.Sprintf("%d days ago", days)
-	case dur.Hours() >= 24:
-		rel = "1 day ago"
-	case dur.Hours() >= 2:
-		hours := int(dur.Hours())
-		rel = fmt.Sprintf("%d hours ago", hours)
-	case dur.Minutes() >= 60:
-		rel = "1 hour ago"
-	default:
-		mins := int(dur.Minutes())
-		if mins > 0 {
-			rel = fmt.Sprintf("%d min ago", mins)
-		} else {
-			rel = "just now"
-		}
-	}
+	rel := formatAge(startedAt)
 	dtStr := startedAt.Format("Mon 2006-01-02 15:04:05 -07:00")
 	if colorEnabled {
 		out.Printf("%sStarted%s     : %s (%s)\n", ColorMagenta, ColorReset, rel, dtStr)
 	} else {
 		out.Printf("Started     : %s (%s)\n", rel, dtStr)
 	}
+	// Session / controlling terminal
+	if session := describeSession(proc.PID, proc.Session); session != "" {
+		if colorEnabled {
+			out.Printf("%sSession%s     : %s\n", ColorMagenta, ColorReset, session)
+		} else {
+			out.Printf("Session     : %s\n", session)
+		}
+	}
 
 	// Why It Exists (short chain)
 	if colorEnabled {
@@ -233,7 +285,26 @@ func RenderStandard(w io.Writer, r model.Result, colorEnabled bool, verbose bool
 	// Source
 	sourceLabel := string(r.Source.Type)
 	sourceName := SanitizeTerminal(r.Source.Name)
-	if colorEnabled {
+	if trigger, owner := r.Source.Details["trigger"], r.Source.Details["user_manager"]; trigger != "" || owner != "" {
+		if trigger != "" {
+			// A timer, socket or path unit started the service
+			sourceName = "started by " + SanitizeTerminal(trigger)
+			if summary := r.Source.Details["trigger_summary"]; summary != "" {
+				sourceName += " (" + SanitizeTerminal(summary) + ")"
+			}
+		} else {
+			// A systemd --user instance manages the unit
+			sourceName += " (" + SanitizeTerminal(owner) + ")"
+			if unit := r.Source.Details["unit"]; unit != "" {
+				sourceName += ": " + SanitizeTerminal(unit)
+			}
+		}
+		if colorEnabled {
+			out.Printf("%sSource%s      : %s\n", ColorCyan, ColorReset, sourceName)
+		} else {
+			out.Printf("Source      : %s\n", sourceName)
+		}
+	} else if colorEnabled {
 		if r.Source.Name != "" && r.Source.Name != sourceLabel {
 			out.Printf("%sSource%s      : %s (%s)\n", ColorCyan, ColorReset, sourceName, sourceLabel)
 		} else {
@@ -266,6 +337,14 @@ func RenderStandard(w io.Writer, r model.Result, colorEnabled bool, verbose bool
 			label = "Registry Key"
 		case model.SourceBsdRc:
 			label = "Rc Script"
+		case model.SourceCron:
+			label = "Crontab"
+		case model.SourceAt:
+			label = "Job File"
+		}
+		file := r.Source.UnitFile
+		if line := r.Source.Details["cron_line"]; r.Source.Type == model.SourceCron && line != "" {
+			file += ":" + line
 		}
 
 		var pad string
@@ -276,12 +355,22 @@ func RenderStandard(w io.Writer, r model.Result, colorEnabled bool, verbose bool
 		}
 
 		if colorEnabled {
-			out.Printf("%s%s%s%s: %s\n", ColorCyan, label, ColorReset, pad, r.Source.UnitFile)
+			out.Printf("%s%s%s%s: %s\n", ColorCyan, label, ColorReset, pad, file)
 		} else {
-			out.Printf("%s%s: %s\n", label, pad, r.Source.UnitFile)
+			out.Printf("%s%s: %s\n", label, pad, file)
 		}
 	}
 
+	if r.Source.Type == model.SourceSystemd && r.Source.Details["unit"] != "" {
+		renderUnit(out, r.Source.Details, colorEnabled)
+	}
+	switch {
+	case r.Source.Type == model.SourceCron && r.Source.Details["schedule"] != "":
+		renderJobRows(out, cronRows, r.Source.Details)
+	case r.Source.Type == model.SourceAt && r.Source.Details["at_job"] != "":
+		renderJobRows(out, atRows, r.Source.Details)
+	}
+
 	// Source details (launchd triggers, plist path, etc.)
 	if len(r.Source.Details) > 0 {
 		// Display in consistent order
@@ -298,29 +387,39 @@ func RenderStandard(w io.Writer, r model.Result, colorEnabled bool, verbose bool
 		}
 	}
 
+	// Zombie / orphan explanation
+	if r.Zombie != nil {
+		renderZombie(out, r.Zombie, colorEnabled)
+	}
+	if r.Orphan != nil {
+		renderOrphan(out, r.Orphan, colorEnabled)
+	}
+	if r.Signals != nil {
+		renderSignalNotes(out, r.Signals, colorEnabled)
+	}
+	if r.Tracer != nil {
+		renderTracer(out, r.Tracer, colorEnabled)
+	}
+
 	// Context group
 	if colorEnabled {
 		if proc.WorkingDir != "" && proc.WorkingDir != "unknown" {
 			out.Printf("\n%sWorking Dir%s : %s\n", ColorCyan, ColorReset, proc.WorkingDir)
 		}
-		if proc.GitRepo != "" {
-			if proc.GitBranch != "" {
-				out.Printf("%sGit Repo%s    : %s (%s)\n", ColorCyan, ColorReset, proc.GitRepo, proc.GitBranch)
-			} else {
-				out.Printf("%sGit Repo%s    : %s\n", ColorCyan, ColorReset, proc.GitRepo)
-			}
-		}
 	} else {
 		if proc.WorkingDir != "" && proc.WorkingDir != "unknown" {
 			out.Printf("\nWorking Dir : %s\n", proc.WorkingDir)
 		}
-		if proc.GitRepo != "" {
-			if proc.GitBranch != "" {
-				out.Printf("Git Repo    : %s (%s)\n", proc.GitRepo, proc.GitBranch)
-			} else {
-				out.Printf("Git Repo    : %s\n", proc.GitRepo)
-			}
-		}
+	}
+
+	if proc.GitRepo != "" {
+		renderGit(out, proc, colorEnabled)
+	}
+	if r.Build != nil {
+		renderBuild(out, r.Build, colorEnabled)
+	}
+	if r.Package != nil {
+		renderPackage(out, r.Package, colorEnabled)
 	}
 
 	// Listening section (address:port)
@@ -583,6 +682,11 @@ func RenderStandard(w io.Writer, r model.Result, colorEnabled bool, verbose bool
 			}
 		}
 
+		// Signal masks
+		if r.Signals != nil {
+			renderSignalMasks(out, r.Signals, colorEnabled)
+		}
+
 		// Threads
 		if proc.ThreadCount > 1 {
 			if colorEnabled {
@@ -0,0 +1,37 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+// renderTracer explains who is attached to the process via ptrace
+func renderTracer(out Printer, t *model.TracerInfo, colorEnabled bool) {
+	labelColor, reset := ansiString(""), ansiString("")
+	if colorEnabled {
+		labelColor, reset = ColorRed, ColorReset
+	}
+
+	name := t.Command
+	if name == "" {
+		name = "unknown"
+	}
+	out.Printf("\n%sTraced By%s   : %s (pid %d, %s)", labelColor, reset, name, t.PID, t.Kind)
+	if t.User != "" {
+		out.Printf(" as %s", t.User)
+	}
+	out.Println()
+	if t.Description != "" {
+		out.Printf("              %s\n", t.Description)
+	}
+	if len(t.Ancestry) > 0 {
+		chain := make([]string, 0, len(t.Ancestry))
+		for _, p := range t.Ancestry {
+			chain = append(chain, fmt.Sprintf("%s (pid %d)", p.Command, p.PID))
+		}
+		out.Printf("              Started via: %s\n", strings.Join(chain, " → "))
+		out.Printf("              Tracer source: %s\n", sourceLabel(t.Source))
+	}
+}
@@ -1,13 +1,16 @@
 package output
 
 import (
+	"fmt"
 	"io"
 	"strings"
 
 	"github.com/pranshuparmar/witr/pkg/model"
 )
 
-func PrintTree(w io.Writer, chain []model.Process, children []model.Process, colorEnabled bool) {
+// PrintTree renders the ancestry chain down to the target, followed by the
+// target's descendant tree when one was resolved.
+func PrintTree(w io.Writer, chain []model.Process, tree *model.ProcessTree, colorEnabled bool) {
 	p := NewPrinter(w)
 
 	for i, proc := range chain {
@@ -25,41 +28,107 @@ func PrintTree(w io.Writer, chain []model.Process, children []model.Process, col
 			if i == len(chain)-1 {
 				cmdColor = ColorGreen
 			}
-			p.Printf("%s%s%s (%spid %d%s)\n", cmdColor, proc.Command, ColorReset, ColorBold, proc.PID, ColorReset)
+			p.Printf("%s%s%s (%spid %d%s)", cmdColor, proc.Command, ColorReset, ColorBold, proc.PID, ColorReset)
 		} else {
-			p.Printf("%s (pid %d)\n", proc.Command, proc.PID)
+			p.Printf("%s (pid %d)", proc.Command, proc.PID)
 		}
+		if i == len(chain)-1 && tree != nil {
+			p.Print(treeUsage(tree, colorEnabled))
+		}
+		p.Println()
 	}
 
-	if len(children) == 0 {
+	if tree == nil {
 		return
 	}
 
 	baseIndent := strings.Repeat("  ", len(chain))
+	printTreeChildren(p, tree, baseIndent, colorEnabled)
+}
 
-	limit := 10
-	count := len(children)
-	for i, child := range children {
-		if i >= limit {
-			remaining := count - limit
-			if colorEnabled {
-				p.Printf("%s%s└─ %s... and %d more\n", baseIndent, ColorMagenta, ColorReset, remaining)
-			} else {
-				p.Printf("%s└─ ... and %d more\n", baseIndent, remaining)
-			}
-			break
-		}
+func printTreeChildren(p Printer, node *model.ProcessTree, prefix string, colorEnabled bool) {
+	branchColor, bold, reset := ansiString(""), ansiString(""), ansiString("")
+	if colorEnabled {
+		branchColor, bold, reset = ColorMagenta, ColorBold, ColorReset
+	}
 
-		connector := "├─ "
-		isLast := (i == count-1) || (i == limit-1 && count <= limit)
-		if isLast {
-			connector = "└─ "
+	for i, child := range node.Children {
+		last := i == len(node.Children)-1 && node.Hidden == 0
+		connector, next := "├─ ", "│  "
+		if last {
+			connector, next = "└─ ", "   "
 		}
 
-		if colorEnabled {
-			p.Printf("%s%s%s%s%s (%spid %d%s)\n", baseIndent, ColorMagenta, connector, ColorReset, child.Command, ColorBold, child.PID, ColorReset)
+		p.Printf("%s%s%s%s", prefix, branchColor, connector, reset)
+		if child.Count > 1 {
+			label := child.Cmdline
+			if label == "" {
+				label = child.Command
+			}
+			p.Printf("× %d %s (%spids %s%s)", child.Count, truncateLabel(label, 60), bold, formatPIDRange(child.PIDs), reset)
 		} else {
-			p.Printf("%s%s%s (pid %d)\n", baseIndent, connector, child.Command, child.PID)
+			p.Printf("%s (%spid %d%s)", child.Command, bold, child.PID, reset)
 		}
+		p.Print(treeUsage(child, colorEnabled))
+		p.Println()
+
+		printTreeChildren(p, child, prefix+next, colorEnabled)
+	}
+
+	if node.Hidden > 0 {
+		p.Printf("%s%s└─ %s… %d more below depth limit\n", prefix, branchColor, reset, node.Hidden)
+	}
+}
+
+// treeUsage formats a node's CPU and RSS, plus the subtree roll-up for inner nodes
+func treeUsage(node *model.ProcessTree, colorEnabled bool) ansiString {
+	dim, reset := "", ""
+	if colorEnabled {
+		dim, reset = string(ColorBold), string(ColorReset)
+	}
+
+	s := fmt.Sprintf("  %s%.1f%% cpu, %s", dim, node.CPUPercent, formatRSS(node.MemoryRSS))
+	if len(node.Children) > 0 || node.Hidden > 0 {
+		s += fmt.Sprintf(" (subtree %.1f%% cpu, %s)", node.TotalCPUPercent, formatRSS(node.TotalMemoryRSS))
+	}
+	return ansiString(s + reset)
+}
+
+func formatRSS(b uint64) string {
+	switch {
+	case b >= 1<<30:
+		return fmt.Sprintf("%.1f GB", float64(b)/(1<<30))
+	case b >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(b)/(1<<20))
+	default:
+		return fmt.Sprintf("%d KB", b>>10)
+	}
+}
+
+// formatPIDRange shortens a sorted PID list to "first–last" when it is contiguous
+func formatPIDRange(pids []int) string {
+	if len(pids) == 0 {
+		return ""
+	}
+	first, last := pids[0], pids[len(pids)-1]
+	if last-first == len(pids)-1 {
+		return fmt.Sprintf("%d–%d", first, last)
+	}
+	parts := make([]string, 0, 4)
+	for i, pid := range pids {
+		if i == 3 {
+			parts = append(parts, "…")
+			break
+		}
+		parts = append(parts, fmt.Sprint(pid))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func truncateLabel(s string, max int) string {
+	r := []rune(s)
+	if len(r) <= max {
+		return s
 	}
+	return string(r[:max-1]) + "…"
 }
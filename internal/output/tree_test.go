@@ -0,0 +1,41 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+func TestPrintTreeDescendants(t *testing.T) {
+	chain := []model.Process{
+		{PID: 1, Command: "systemd"},
+		{PID: 100, Command: "php-fpm"},
+	}
+	tree := &model.ProcessTree{
+		PID:            100,
+		Command:        "php-fpm",
+		MemoryRSS:      10 << 20,
+		TotalMemoryRSS: 90 << 20,
+		Children: []*model.ProcessTree{
+			{PID: 101, Command: "php-fpm", Cmdline: "php-fpm: pool www", Count: 32, PIDs: []int{101, 102, 132}, MemoryRSS: 64 << 20, TotalMemoryRSS: 64 << 20},
+			{PID: 200, Command: "sh", Hidden: 2, MemoryRSS: 1 << 20, TotalMemoryRSS: 16 << 20},
+		},
+	}
+
+	var buf bytes.Buffer
+	PrintTree(&buf, chain, tree, false)
+	out := buf.String()
+
+	for _, want := range []string{
+		"php-fpm (pid 100)  0.0% cpu, 10.0 MB (subtree 0.0% cpu, 90.0 MB)",
+		"├─ × 32 php-fpm: pool www (pids 101, 102, 132)",
+		"└─ sh (pid 200)  0.0% cpu, 1.0 MB (subtree 0.0% cpu, 16.0 MB)",
+		"   └─ … 2 more below depth limit",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package output
+
+import "strings"
+
+// unitRows are the Source.Details keys of a systemd unit, in display order
+var unitRows = []struct{ key, label string }{
+	{"exec_start_pre", "ExecStartPre"},
+	{"exec_start", "ExecStart"},
+	{"user", "User"},
+	{"restart", "Restart"},
+	{"working_directory", "WorkingDir"},
+	{"environment_file", "EnvFile"},
+	{"drop_ins", "Drop-In"},
+	{"enabled", "Enabled"},
+	{"wanted_by", "WantedBy"},
+	{"boot_path", "Boot Path"},
+	{"trigger", "Triggered By"},
+}
+
+// renderUnit prints the effective configuration of the systemd unit behind a
+// process: how it is started, as whom, its restart policy and drop-in overrides.
+func renderUnit(out Printer, d map[string]string, colorEnabled bool) {
+	labelColor, dim, reset := ansiString(""), ansiString(""), ansiString("")
+	if colorEnabled {
+		labelColor, dim, reset = ColorCyan, ColorBold, ColorReset
+	}
+
+	out.Printf("%sUnit%s        : %s", labelColor, reset, d["unit"])
+	if d["state"] != "" {
+		out.Printf(" %s[%s]%s", dim, d["state"], reset)
+	}
+	out.Println()
+
+	for _, row := range unitRows {
+		value := d[row.key]
+		switch row.key {
+		case "user":
+			// The system manager runs units as root without User=;
+			// detection fills in the owner for user managers
+			if value == "" {
+				value = "root"
+			}
+			if g := d["group"]; g != "" {
+				value += " (group " + g + ")"
+			}
+		case "restart":
+			if value != "" && value != "no" && d["restart_sec"] != "" {
+				value += ", after " + d["restart_sec"]
+			}
+		case "trigger":
+			if value != "" && d["trigger_detail"] != "" {
+				value += "\n" + d["trigger_detail"]
+			}
+		}
+		if value == "" {
+			continue
+		}
+		for i, line := range strings.Split(value, "\n") {
+			// Later commands and drop-ins continue under the first one
+			prefix := "              " + row.label + strings.Repeat(" ", 12-len(row.label)) + " : "
+			if i > 0 {
+				prefix = strings.Repeat(" ", 29)
+			}
+			if path, overrides, ok := strings.Cut(line, " ("); row.key == "drop_ins" && ok {
+				out.Printf("%s%s\n", prefix, path)
+				out.Printf("%s%soverrides %s%s\n", strings.Repeat(" ", 29), dim, strings.TrimSuffix(overrides, ")"), reset)
+				continue
+			}
+			out.Printf("%s%s\n", prefix, line)
+		}
+	}
+}
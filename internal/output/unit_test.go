@@ -0,0 +1,42 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestRenderUnit(t *testing.T) {
+	var buf bytes.Buffer
+	renderUnit(NewPrinter(&buf), map[string]string{
+		"unit":           "nginx.service",
+		"state":          "active (running)",
+		"exec_start_pre": "/usr/sbin/nginx -t -q",
+		"exec_start":     "/usr/sbin/nginx -g daemon on;",
+		"group":          "www-data",
+		"restart":        "on-failure",
+		"restart_sec":    "5s",
+		"drop_ins":       "/etc/systemd/system/nginx.service.d/override.conf ([Service] Restart, RestartSec)\n/run/systemd/system/nginx.service.d/50-limits.conf",
+		"enabled":        "enabled by admin",
+		"boot_path":      "default.target → multi-user.target → nginx.service",
+		"trigger":        "nginx.socket",
+		"trigger_detail": "ListenStream=0.0.0.0:80\nListenStream=[::]:80",
+	}, false)
+
+	want := `Unit        : nginx.service [active (running)]
+              ExecStartPre : /usr/sbin/nginx -t -q
+              ExecStart    : /usr/sbin/nginx -g daemon on;
+              User         : root (group www-data)
+              Restart      : on-failure, after 5s
+              Drop-In      : /etc/systemd/system/nginx.service.d/override.conf
+                             overrides [Service] Restart, RestartSec
+                             /run/systemd/system/nginx.service.d/50-limits.conf
+              Enabled      : enabled by admin
+              Boot Path    : default.target → multi-user.target → nginx.service
+              Triggered By : nginx.socket
+                             ListenStream=0.0.0.0:80
+                             ListenStream=[::]:80
+`
+	if buf.String() != want {
+		t.Fatalf("got:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
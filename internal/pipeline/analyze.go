@@ -3,6 +3,7 @@ package pipeline
 import (
 	procpkg "github.com/pranshuparmar/witr/internal/proc"
 	"github.com/pranshuparmar/witr/internal/source"
+	"github.com/pranshuparmar/witr/internal/systemd"
 	"github.com/pranshuparmar/witr/pkg/model"
 )
 
@@ -10,10 +11,14 @@ type AnalyzeConfig struct {
 	PID     int
 	Verbose bool
 	Tree    bool
+	Depth   int // descendant levels expanded in Tree mode, 0 for unlimited
 	Target  model.Target
 }
 
 func AnalyzePID(cfg AnalyzeConfig) (model.Result, error) {
+	// Unit state changes between analyses, e.g. each TUI refresh
+	systemd.Invalidate()
+
 	ancestry, err := procpkg.ResolveAncestry(cfg.PID)
 	if err != nil {
 		return model.Result{}, err
@@ -56,6 +61,40 @@ func AnalyzePID(cfg AnalyzeConfig) (model.Result, error) {
 		}
 	}
 
+	// Explain defunct and reparented processes in terms of their parents
+	zombie := procpkg.ResolveZombie(ancestry)
+	if zombie != nil {
+		zombie.ParentSource = source.Detect(ancestry[:len(ancestry)-1])
+	}
+	orphan := procpkg.ResolveOrphan(ancestry)
+
+	// Kernel threads ignore all signals by design, so there is nothing to explain
+	var signals *model.SignalInfo
+	if !proc.KernelThread {
+		signals = procpkg.ReadSignals(proc.PID)
+		source.ExplainSignals(signals)
+	}
+
+	var build *model.BuildInfo
+	if !proc.KernelThread && proc.PID > 0 {
+		build = procpkg.ReadBuildInfo(proc.PID, proc.Exe)
+	}
+	pkg := source.ResolvePackage(proc)
+	source.VerifyPackage(proc, pkg)
+
+	var tracer *model.TracerInfo
+	if proc.TracerPID > 0 {
+		tracer = &model.TracerInfo{PID: proc.TracerPID, User: proc.TracerUser}
+		if tracerAncestry, err := procpkg.ResolveAncestry(proc.TracerPID); err == nil {
+			tp := tracerAncestry[len(tracerAncestry)-1]
+			tracer.Command = tp.Command
+			tracer.Cmdline = tp.Cmdline
+			tracer.Ancestry = tracerAncestry
+			tracer.Source = source.Detect(tracerAncestry)
+		}
+		source.ClassifyTracer(tracer)
+	}
+
 	var childProcesses []model.Process
 	if (cfg.Verbose || cfg.Tree) && proc.PID > 0 {
 		if children, err := procpkg.ResolveChildren(proc.PID); err == nil {
@@ -63,6 +102,13 @@ func AnalyzePID(cfg AnalyzeConfig) (model.Result, error) {
 		}
 	}
 
+	var tree *model.ProcessTree
+	if cfg.Tree && proc.PID > 0 {
+		if t, err := procpkg.ResolveTree(proc.PID, cfg.Depth); err == nil {
+			tree = t
+		}
+	}
+
 	res := model.Result{
 		Target:          cfg.Target,
 		ResolvedTarget:  resolvedTarget,
@@ -70,10 +116,17 @@ func AnalyzePID(cfg AnalyzeConfig) (model.Result, error) {
 		RestartCount:    restartCount,
 		Ancestry:        ancestry,
 		Source:          src,
-		Warnings:        source.Warnings(ancestry),
+		Warnings:        append(source.Warnings(ancestry), source.IntegrityWarnings(pkg)...),
 		ResourceContext: resCtx,
 		FileContext:     fileCtx,
 		Children:        childProcesses,
+		Tree:            tree,
+		Zombie:          zombie,
+		Orphan:          orphan,
+		Signals:         signals,
+		Tracer:          tracer,
+		Build:           build,
+		Package:         pkg,
 	}
 
 	return res, nil
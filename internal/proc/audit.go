@@ -0,0 +1,73 @@
+package proc
+
+import (
+	"sort"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+// pidProbe is the result of probing a single PID directly
+type pidProbe struct {
+	statOK bool // /proc/<pid> can be stat'ed
+	killOK bool // kill(pid, 0) reports the process exists
+	tgid   int  // thread group, 0 if unknown
+}
+
+// findHidden compares the PIDs listed in /proc against direct probes of every
+// PID up to pidMax and against PIDs referenced by visible processes (children
+// lists and parent PIDs). Threads are skipped: they can be stat'ed and signalled
+// by TID but are never listed at the top level of /proc.
+func findHidden(listed map[int]bool, pidMax int, probe func(pid int) pidProbe, refs map[int]string) []model.HiddenProcess {
+	found := make(map[int]*model.HiddenProcess)
+	add := func(pid int, method string) {
+		h, ok := found[pid]
+		if !ok {
+			h = &model.HiddenProcess{PID: pid}
+			found[pid] = h
+		}
+		h.Methods = append(h.Methods, method)
+	}
+
+	isThread := func(pid int, p pidProbe) bool {
+		return p.tgid > 0 && p.tgid != pid
+	}
+
+	for pid := 1; pid <= pidMax; pid++ {
+		if listed[pid] {
+			continue
+		}
+		p := probe(pid)
+		if isThread(pid, p) {
+			continue
+		}
+		if p.statOK {
+			add(pid, "stat")
+		}
+		if p.killOK {
+			add(pid, "kill")
+		}
+	}
+
+	refPIDs := make([]int, 0, len(refs))
+	for pid := range refs {
+		refPIDs = append(refPIDs, pid)
+	}
+	sort.Ints(refPIDs)
+	for _, pid := range refPIDs {
+		if listed[pid] || pid <= 0 {
+			continue
+		}
+		p := probe(pid)
+		if isThread(pid, p) || (!p.statOK && !p.killOK) {
+			continue
+		}
+		add(pid, refs[pid])
+	}
+
+	hidden := make([]model.HiddenProcess, 0, len(found))
+	for _, h := range found {
+		hidden = append(hidden, *h)
+	}
+	sort.Slice(hidden, func(i, j int) bool { return hidden[i].PID < hidden[j].PID })
+	return hidden
+}
@@ -0,0 +1,157 @@
+//go:build linux
+
+package proc
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+// AuditHiddenProcesses looks for processes that are alive but hidden from the
+// /proc directory listing, as rootkits do by hooking getdents. witr is a static
+// Go binary, so LD_PRELOAD readdir hooks do not affect its own listing.
+func AuditHiddenProcesses() (*model.AuditResult, error) {
+	listed, err := listProcPIDs()
+	if err != nil {
+		return nil, err
+	}
+
+	pidMax := readPIDMax()
+	res := &model.AuditResult{
+		PIDMax: pidMax,
+		Listed: len(listed),
+		Hidden: []model.HiddenProcess{},
+	}
+
+	// PIDs that visible processes point at: children of each task and parents
+	refs := make(map[int]string)
+	for pid := range listed {
+		for _, child := range readTaskChildren(pid) {
+			if !listed[child] {
+				refs[child] = "children"
+			}
+		}
+		if fields, err := readStatFields(pid); err == nil && len(fields) > 1 {
+			if ppid, err := strconv.Atoi(fields[1]); err == nil && ppid > 0 && !listed[ppid] {
+				if _, ok := refs[ppid]; !ok {
+					refs[ppid] = "ppid"
+				}
+			}
+		}
+	}
+
+	candidates := findHidden(listed, pidMax, probePID, refs)
+
+	// Processes started or exited during the scan are not hidden; re-list and
+	// re-probe to rule out races.
+	relisted, err := listProcPIDs()
+	if err != nil {
+		return nil, err
+	}
+	for _, h := range candidates {
+		if relisted[h.PID] {
+			continue
+		}
+		if p := probePID(h.PID); !p.statOK && !p.killOK {
+			continue
+		}
+		describeHidden(&h)
+		res.Hidden = append(res.Hidden, h)
+	}
+
+	return res, nil
+}
+
+func listProcPIDs() (map[int]bool, error) {
+	entries, err := os.ReadDir("/proc")
+	if err != nil {
+		return nil, fmt.Errorf("read /proc: %w", err)
+	}
+	pids := make(map[int]bool, len(entries))
+	for _, entry := range entries {
+		if pid, err := strconv.Atoi(entry.Name()); err == nil {
+			pids[pid] = true
+		}
+	}
+	return pids, nil
+}
+
+func readPIDMax() int {
+	data, err := os.ReadFile("/proc/sys/kernel/pid_max")
+	if err != nil {
+		return 32768
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil || n <= 0 {
+		return 32768
+	}
+	return n
+}
+
+// readTaskChildren returns the children of every thread of pid
+func readTaskChildren(pid int) []int {
+	tasks, err := os.ReadDir(fmt.Sprintf("/proc/%d/task", pid))
+	if err != nil {
+		return nil
+	}
+	var children []int
+	for _, task := range tasks {
+		data, err := os.ReadFile(fmt.Sprintf("/proc/%d/task/%s/children", pid, task.Name()))
+		if err != nil {
+			continue
+		}
+		for _, f := range strings.Fields(string(data)) {
+			if child, err := strconv.Atoi(f); err == nil {
+				children = append(children, child)
+			}
+		}
+	}
+	return children
+}
+
+func probePID(pid int) pidProbe {
+	var p pidProbe
+	var st syscall.Stat_t
+	if syscall.Stat(fmt.Sprintf("/proc/%d", pid), &st) == nil {
+		p.statOK = true
+	}
+	if err := syscall.Kill(pid, 0); err == nil || err == syscall.EPERM {
+		p.killOK = true
+	}
+	if p.statOK || p.killOK {
+		p.tgid = readStatusInt(pid, "Tgid:")
+	}
+	return p
+}
+
+func readStatusInt(pid int, key string) int {
+	data, err := os.ReadFile(fmt.Sprintf("/proc/%d/status", pid))
+	if err != nil {
+		return 0
+	}
+	for line := range strings.Lines(string(data)) {
+		if value, ok := strings.CutPrefix(line, key); ok {
+			n, _ := strconv.Atoi(strings.TrimSpace(value))
+			return n
+		}
+	}
+	return 0
+}
+
+// describeHidden fills in whatever can still be read through the direct path
+func describeHidden(h *model.HiddenProcess) {
+	h.Command = readComm(h.PID)
+	h.PPID = readStatusInt(h.PID, "PPid:")
+	if data, err := os.ReadFile(fmt.Sprintf("/proc/%d/cmdline", h.PID)); err == nil {
+		h.Cmdline = strings.TrimSpace(strings.ReplaceAll(string(data), "\x00", " "))
+	}
+	if exe, err := os.Readlink(fmt.Sprintf("/proc/%d/exe", h.PID)); err == nil {
+		h.Exe = exe
+	}
+	h.User = readUser(h.PID)
+}
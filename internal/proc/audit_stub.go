@@ -0,0 +1,10 @@
+//go:build !linux
+
+package proc
+
+import "github.com/pranshuparmar/witr/pkg/model"
+
+// AuditHiddenProcesses relies on /proc and is only available on Linux
+func AuditHiddenProcesses() (*model.AuditResult, error) {
+	return &model.AuditResult{Skipped: "hidden process audit requires Linux /proc"}, nil
+}
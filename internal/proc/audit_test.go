@@ -0,0 +1,37 @@
+package proc
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestFindHidden(t *testing.T) {
+	listed := map[int]bool{1: true, 10: true, 20: true}
+	probes := map[int]pidProbe{
+		1:  {statOK: true, killOK: true, tgid: 1},
+		10: {statOK: true, killOK: true, tgid: 10},
+		11: {statOK: true, killOK: true, tgid: 10}, // thread of 10
+		20: {statOK: true, killOK: true, tgid: 20},
+		30: {statOK: true, killOK: true, tgid: 30}, // hidden from readdir
+		40: {killOK: true},                         // stat hooked too, only kill sees it
+		50: {statOK: true, killOK: true, tgid: 50}, // hidden, referenced as a child
+	}
+	probe := func(pid int) pidProbe { return probes[pid] }
+	refs := map[int]string{50: "children", 60: "ppid"} // 60 is gone
+
+	hidden := findHidden(listed, 64, probe, refs)
+
+	var pids []int
+	for _, h := range hidden {
+		pids = append(pids, h.PID)
+	}
+	if !slices.Equal(pids, []int{30, 40, 50}) {
+		t.Fatalf("unexpected hidden pids: %v", pids)
+	}
+	if !slices.Equal(hidden[1].Methods, []string{"kill"}) {
+		t.Fatalf("unexpected methods for pid 40: %v", hidden[1].Methods)
+	}
+	if !slices.Equal(hidden[2].Methods, []string{"stat", "kill", "children"}) {
+		t.Fatalf("unexpected methods for pid 50: %v", hidden[2].Methods)
+	}
+}
@@ -0,0 +1,104 @@
+package proc
+
+import (
+	"bytes"
+	"debug/buildinfo"
+	"debug/elf"
+	"encoding/binary"
+	"encoding/hex"
+	"time"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+// ntGNUBuildID is the ELF note type carrying the linker-generated build-id
+const ntGNUBuildID = 3
+
+// ReadBuildInfo reports which build of its binary the process is running.
+// It returns nil when the executable is unreadable or carries no build data.
+func ReadBuildInfo(pid int, exe string) *model.BuildInfo {
+	return readBuildInfo(buildInfoPath(pid, exe))
+}
+
+func readBuildInfo(path string) *model.BuildInfo {
+	if path == "" {
+		return nil
+	}
+
+	info := &model.BuildInfo{}
+	if bi, err := buildinfo.ReadFile(path); err == nil {
+		info.GoVersion = bi.GoVersion
+		info.Module = bi.Main.Path
+		info.Version = bi.Main.Version
+		for _, s := range bi.Settings {
+			switch s.Key {
+			case "vcs.revision":
+				info.Revision = s.Value
+			case "vcs.time":
+				if t, err := time.Parse(time.RFC3339, s.Value); err == nil {
+					info.RevisionTime = t
+				}
+			case "vcs.modified":
+				info.Modified = s.Value == "true"
+			}
+		}
+	}
+	info.BuildID = readELFBuildID(path)
+
+	if info.GoVersion == "" && info.BuildID == "" {
+		return nil
+	}
+	return info
+}
+
+// readELFBuildID returns the hex GNU build-id note of an ELF binary
+func readELFBuildID(path string) string {
+	f, err := elf.Open(path)
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+
+	if sec := f.Section(".note.gnu.build-id"); sec != nil {
+		if data, err := sec.Data(); err == nil {
+			if id := parseBuildIDNote(data, f.ByteOrder); id != "" {
+				return id
+			}
+		}
+	}
+	// Stripped binaries may lack section headers but keep PT_NOTE segments
+	for _, prog := range f.Progs {
+		if prog.Type != elf.PT_NOTE || prog.Filesz > 1<<20 {
+			continue
+		}
+		data := make([]byte, prog.Filesz)
+		if _, err := prog.ReadAt(data, 0); err != nil {
+			continue
+		}
+		if id := parseBuildIDNote(data, f.ByteOrder); id != "" {
+			return id
+		}
+	}
+	return ""
+}
+
+// parseBuildIDNote scans a sequence of ELF notes for NT_GNU_BUILD_ID owned by "GNU"
+func parseBuildIDNote(data []byte, order binary.ByteOrder) string {
+	align4 := func(n uint32) uint32 { return (n + 3) &^ 3 }
+	for len(data) >= 12 {
+		nameSz := order.Uint32(data[0:4])
+		descSz := order.Uint32(data[4:8])
+		typ := order.Uint32(data[8:12])
+		data = data[12:]
+		if uint64(align4(nameSz))+uint64(align4(descSz)) > uint64(len(data)) {
+			return ""
+		}
+		name := data[:nameSz]
+		desc := data[align4(nameSz) : align4(nameSz)+descSz]
+		if typ == ntGNUBuildID && bytes.Equal(bytes.TrimRight(name, "\x00"), []byte("GNU")) {
+			return hex.EncodeToString(desc)
+		}
+		data = data[align4(nameSz)+align4(descSz):]
+	}
+	return ""
+}
@@ -0,0 +1,11 @@
+//go:build linux
+
+package proc
+
+import "fmt"
+
+// buildInfoPath reads through /proc so replaced or deleted binaries still
+// report the build that is actually running.
+func buildInfoPath(pid int, exe string) string {
+	return fmt.Sprintf("/proc/%d/exe", pid)
+}
@@ -0,0 +1,7 @@
+//go:build !linux
+
+package proc
+
+func buildInfoPath(pid int, exe string) string {
+	return exe
+}
@@ -0,0 +1,68 @@
+package proc
+
+import (
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestReadBuildInfoGoBinary(t *testing.T) {
+	exe, err := os.Executable()
+	if err != nil {
+		t.Skip("no executable path")
+	}
+	info := readBuildInfo(exe)
+	if info == nil {
+		t.Fatal("expected build info for the test binary")
+	}
+	if info.GoVersion != runtime.Version() {
+		t.Fatalf("GoVersion = %q, want %q", info.GoVersion, runtime.Version())
+	}
+}
+
+func TestReadBuildInfoNotABinary(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "script.sh")
+	if err := os.WriteFile(path, []byte("#!/bin/sh\necho hi\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if info := readBuildInfo(path); info != nil {
+		t.Fatalf("expected nil for a script, got %+v", info)
+	}
+	if info := readBuildInfo(""); info != nil {
+		t.Fatalf("expected nil for empty path, got %+v", info)
+	}
+}
+
+func TestParseBuildIDNote(t *testing.T) {
+	note := func(name string, typ uint32, desc []byte) []byte {
+		nameBytes := append([]byte(name), 0)
+		buf := binary.LittleEndian.AppendUint32(nil, uint32(len(nameBytes)))
+		buf = binary.LittleEndian.AppendUint32(buf, uint32(len(desc)))
+		buf = binary.LittleEndian.AppendUint32(buf, typ)
+		buf = append(buf, nameBytes...)
+		for len(buf)%4 != 0 {
+			buf = append(buf, 0)
+		}
+		buf = append(buf, desc...)
+		for len(buf)%4 != 0 {
+			buf = append(buf, 0)
+		}
+		return buf
+	}
+
+	// An ABI tag note precedes the build-id in typical PT_NOTE segments
+	data := append(note("GNU", 1, []byte{0, 0, 0, 0, 3, 0, 0, 0, 2, 0, 0, 0, 0, 0, 0, 0}),
+		note("GNU", ntGNUBuildID, []byte{0xde, 0xad, 0xbe, 0xef, 0x01})...)
+	if got := parseBuildIDNote(data, binary.LittleEndian); got != "deadbeef01" {
+		t.Fatalf("parseBuildIDNote = %q, want deadbeef01", got)
+	}
+
+	if got := parseBuildIDNote(note("Go", 4, []byte("abc")), binary.LittleEndian); got != "" {
+		t.Fatalf("expected no build-id from Go note, got %q", got)
+	}
+	if got := parseBuildIDNote(data[:20], binary.LittleEndian); got != "" {
+		t.Fatalf("expected no build-id from truncated note, got %q", got)
+	}
+}
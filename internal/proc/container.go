@@ -4,16 +4,43 @@ import (
 	"context"
 	"fmt"
 	"os/exec"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 	"unicode"
 
+	"github.com/pranshuparmar/witr/internal/docker"
 	"github.com/pranshuparmar/witr/pkg/model"
 )
 
-// ResolveContainerByPort queries the Docker CLI for a container publishing the given port.
+// ResolveContainerByPort finds the Docker container publishing the given port,
+// asking the engine API and falling back to the docker CLI.
 // Returns nil if Docker is not available or no container matches.
 func ResolveContainerByPort(port int) *model.DockerPortMatch {
+	if c, err := docker.Connect("docker"); err == nil {
+		ctx, cancel := context.WithTimeout(context.Background(), docker.Timeout)
+		defer cancel()
+		if list, err := c.List(ctx, map[string][]string{"publish": {strconv.Itoa(port)}}); err == nil {
+			if len(list) == 0 {
+				return nil
+			}
+			// Take the first matching container
+			s := list[0]
+			return &model.DockerPortMatch{
+				ID:             s.ID,
+				Name:           s.Name(),
+				Image:          s.Image,
+				Ports:          s.PortsString(),
+				ComposeProject: s.Labels[docker.LabelComposeProject],
+				ComposeService: s.Labels[docker.LabelComposeService],
+			}
+		}
+	}
+	return resolveContainerByPortCLI(port)
+}
+
+func resolveContainerByPortCLI(port int) *model.DockerPortMatch {
 	if _, err := exec.LookPath("docker"); err != nil {
 		return nil
 	}
@@ -53,6 +80,125 @@ func ResolveContainerByPort(port int) *model.DockerPortMatch {
 	}
 }
 
+// resolveContainer labels a Docker or Podman container and collects its
+// engine-reported details over the API, falling back to the runtime CLI for
+// the label alone.
+func resolveContainer(id, runtime string) (string, *model.ContainerInfo) {
+	if info := inspectContainer(id, runtime); info != nil {
+		return containerLabel(info), info
+	}
+	return resolveContainerName(id, runtime), nil
+}
+
+// inspectContainer fetches a container's state from the engine API
+func inspectContainer(id, runtime string) *model.ContainerInfo {
+	c, err := docker.Connect(runtime)
+	if err != nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), docker.Timeout)
+	defer cancel()
+	ctr, err := c.Inspect(ctx, id)
+	if err != nil {
+		return nil
+	}
+	return containerInfo(ctr, runtime)
+}
+
+func containerInfo(c *docker.Container, runtime string) *model.ContainerInfo {
+	info := &model.ContainerInfo{
+		Runtime:        runtime,
+		ID:             c.ID,
+		Name:           c.ContainerName(),
+		Image:          c.Config.Image,
+		ComposeProject: c.Config.Labels[docker.LabelComposeProject],
+		ComposeService: c.Config.Labels[docker.LabelComposeService],
+		RestartPolicy:  c.HostConfig.RestartPolicy.Name,
+		RestartCount:   c.RestartCount,
+		Restarting:     c.State.Restarting,
+		Healthcheck:    c.HasHealthcheck(),
+		Networks:       c.NetworkNames(),
+		Ports:          c.PortBindings(),
+	}
+	if t, err := time.Parse(time.RFC3339Nano, c.State.StartedAt); err == nil && t.Year() > 1 {
+		info.StartedAt = t
+	}
+	if n := c.HostConfig.RestartPolicy.MaximumRetryCount; info.RestartPolicy == "on-failure" && n > 0 {
+		info.RestartPolicy += ":" + strconv.Itoa(n)
+	}
+	if h := c.State.Health; h != nil {
+		info.Health = h.Status
+		info.FailingStreak = h.FailingStreak
+		if len(h.Log) > 0 {
+			info.HealthOutput = probeOutput(h.Log[len(h.Log)-1].Output)
+		}
+	}
+	return info
+}
+
+// probeOutput keeps the first line of a healthcheck probe's output, which is
+// where curl, pg_isready and friends put the reason
+func probeOutput(s string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(s), "\n")
+	line = strings.TrimSpace(line)
+	if len(line) > 120 {
+		line = line[:117] + "..."
+	}
+	return line
+}
+
+// containerLabel formats a container as "docker: project/service (name)" for
+// compose services and "docker: name" or "podman: name" otherwise
+func containerLabel(info *model.ContainerInfo) string {
+	if info.ComposeProject != "" && info.ComposeService != "" {
+		return info.Runtime + ": " + info.ComposeProject + "/" + info.ComposeService + " (" + info.Name + ")"
+	}
+	if info.Name != "" {
+		return info.Runtime + ": " + info.Name
+	}
+	return ""
+}
+
+// resolveDockerProxyContainer names the container a docker-proxy process
+// forwards to, by its -container-ip on any network
+func resolveDockerProxyContainer(cmdline string) string {
+	containerIP := extractFlagValue(cmdline, "-container-ip")
+	if containerIP == "" {
+		return ""
+	}
+
+	if c, err := docker.Connect("docker"); err == nil {
+		ctx, cancel := context.WithTimeout(context.Background(), docker.Timeout)
+		defer cancel()
+		if s, err := c.ContainerByIP(ctx, containerIP); err == nil {
+			if s == nil {
+				return ""
+			}
+			return "target: " + s.Name()
+		}
+	}
+
+	if _, err := exec.LookPath("docker"); err != nil {
+		return ""
+	}
+	ids, err := exec.Command("docker", "ps", "-q").Output()
+	if err != nil || len(strings.TrimSpace(string(ids))) == 0 {
+		return ""
+	}
+	args := append([]string{"inspect", "--format", "{{.Name}}{{range .NetworkSettings.Networks}} {{.IPAddress}}{{end}}"}, strings.Fields(string(ids))...)
+	out, err := exec.Command("docker", args...).Output()
+	if err != nil {
+		return ""
+	}
+	for line := range strings.Lines(string(out)) {
+		fields := strings.Fields(line)
+		if len(fields) > 1 && slices.Contains(fields[1:], containerIP) {
+			return "target: " + strings.TrimPrefix(fields[0], "/")
+		}
+	}
+	return ""
+}
+
 // resolveContainerName attempts to resolve a container ID to a name using the specified runtime CLI.
 func resolveContainerName(id, runtime string) string {
 	var cmd *exec.Cmd
@@ -1,7 +1,15 @@
 package proc
 
 import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
 	"testing"
+
+	"github.com/pranshuparmar/witr/internal/docker"
+	"github.com/pranshuparmar/witr/pkg/model"
 )
 
 func TestSplitCmdline(t *testing.T) {
@@ -69,3 +77,54 @@ func TestExtractFlagValue(t *testing.T) {
 		})
 	}
 }
+
+func TestContainerInfo(t *testing.T) {
+	var c docker.Container
+	err := json.Unmarshal([]byte(`{
+		"Id": "3f2a9c", "Name": "/shop-api-1", "RestartCount": 3,
+		"Config": {"Image": "ghcr.io/acme/api:1.4", "Labels": {"com.docker.compose.project": "shop", "com.docker.compose.service": "api"}},
+		"State": {"Restarting": true, "StartedAt": "2024-03-01T10:15:30.123456789Z", "Health": {"Status": "unhealthy", "FailingStreak": 2, "Log": [
+			{"ExitCode": 0, "Output": "ok"},
+			{"ExitCode": 1, "Output": "\n  pg_isready: no response\nexit 2\n"}
+		]}},
+		"HostConfig": {"RestartPolicy": {"Name": "on-failure", "MaximumRetryCount": 5}},
+		"NetworkSettings": {"Networks": {"shop_default": {"IPAddress": "172.18.0.3"}}}
+	}`), &c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	info := containerInfo(&c, "docker")
+	if info.RestartPolicy != "on-failure:5" || info.RestartCount != 3 || !info.Restarting || info.StartedAt.Unix() != 1709288130 {
+		t.Errorf("restart state: %+v", info)
+	}
+	if !info.Healthcheck || info.Health != "unhealthy" || info.FailingStreak != 2 || info.HealthOutput != "pg_isready: no response" {
+		t.Errorf("health: %+v", info)
+	}
+	if got := containerLabel(info); got != "docker: shop/api (shop-api-1)" {
+		t.Errorf("label = %q", got)
+	}
+	if got := containerLabel(&model.ContainerInfo{Runtime: "podman", Name: "web"}); got != "podman: web" {
+		t.Errorf("label = %q", got)
+	}
+}
+
+func TestResolveDockerProxyContainerAPI(t *testing.T) {
+	sock := filepath.Join(t.TempDir(), "docker.sock")
+	ln, err := net.Listen("unix", sock)
+	if err != nil {
+		t.Skipf("unix sockets unavailable: %v", err)
+	}
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"Id": "77b0e1", "Names": ["/db"], "NetworkSettings": {"Networks": {"backend": {"IPAddress": "172.21.0.4"}}}}]`))
+	}))
+	srv.Listener = ln
+	srv.Start()
+	defer srv.Close()
+	t.Setenv("DOCKER_HOST", "unix://"+sock)
+
+	got := resolveDockerProxyContainer("/usr/bin/docker-proxy -proto tcp -host-ip 0.0.0.0 -host-port 5432 -container-ip 172.21.0.4 -container-port 5432")
+	if got != "target: db" {
+		t.Fatalf("got %q", got)
+	}
+}
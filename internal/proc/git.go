@@ -0,0 +1,262 @@
+package proc
+
+import (
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+// readGitInfo walks up from cwd to the enclosing git checkout and reads its
+// branch, commit and remote straight from the repository files, without
+// running git. exePath, when known, is compared against HEAD to spot binaries
+// built before the current checkout.
+func readGitInfo(cwd, exePath string) *model.GitInfo {
+	if cwd == "" || cwd == "unknown" || cwd == "invalid" {
+		return nil
+	}
+
+	root, gitDir, isFile := findGitDir(cwd)
+	if root == "" {
+		return nil
+	}
+
+	info := &model.GitInfo{Root: root}
+
+	// Linked worktrees keep refs and config in the main repository's git dir
+	commonDir := gitDir
+	if data, err := os.ReadFile(filepath.Join(gitDir, "commondir")); err == nil {
+		commonDir = resolveGitPath(gitDir, strings.TrimSpace(string(data)))
+		info.Worktree = true
+	} else if isFile && strings.Contains(filepath.ToSlash(gitDir), "/modules/") {
+		info.Submodule = true
+	}
+
+	head, err := os.ReadFile(filepath.Join(gitDir, "HEAD"))
+	if err != nil {
+		return info
+	}
+	headStr := strings.TrimSpace(string(head))
+	if ref, ok := strings.CutPrefix(headStr, "ref: "); ok {
+		info.Branch = strings.TrimPrefix(ref, "refs/heads/")
+		info.Commit = resolveGitRef(gitDir, commonDir, ref)
+	} else {
+		info.Detached = true
+		info.Commit = headStr
+	}
+
+	info.Remote, info.RemoteURL = readGitRemote(commonDir, info.Branch)
+
+	info.HeadTime = readCommitTime(commonDir, info.Commit)
+	if info.HeadTime.IsZero() {
+		info.HeadTime = readReflogTime(gitDir)
+	}
+
+	if exePath != "" && isWithin(root, exePath) {
+		info.BinaryInRepo = true
+		if fi, err := os.Stat(exePath); err == nil && !info.HeadTime.IsZero() {
+			info.BinaryNewer = fi.ModTime().After(info.HeadTime)
+		}
+	}
+
+	return info
+}
+
+// findGitDir returns the work tree root and git dir for cwd. .git is either a
+// directory or, for worktrees and submodules, a file containing "gitdir: <path>".
+func findGitDir(cwd string) (root, gitDir string, isFile bool) {
+	dir := filepath.Clean(cwd)
+	for {
+		candidate := filepath.Join(dir, ".git")
+		if fi, err := os.Stat(candidate); err == nil {
+			if fi.IsDir() {
+				return dir, candidate, false
+			}
+			if data, err := os.ReadFile(candidate); err == nil {
+				if path, ok := strings.CutPrefix(strings.TrimSpace(string(data)), "gitdir:"); ok {
+					return dir, resolveGitPath(dir, strings.TrimSpace(path)), true
+				}
+			}
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", "", false
+		}
+		dir = parent
+	}
+}
+
+func resolveGitPath(base, path string) string {
+	if filepath.IsAbs(path) {
+		return filepath.Clean(path)
+	}
+	return filepath.Join(base, path)
+}
+
+// resolveGitRef follows a ref through loose ref files and packed-refs
+func resolveGitRef(gitDir, commonDir, ref string) string {
+	for range 5 { // symbolic refs may chain
+		var value string
+		for _, dir := range []string{gitDir, commonDir} {
+			if data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(ref))); err == nil {
+				value = strings.TrimSpace(string(data))
+				break
+			}
+		}
+		if value == "" {
+			return readPackedRef(commonDir, ref)
+		}
+		next, symbolic := strings.CutPrefix(value, "ref: ")
+		if !symbolic {
+			return value
+		}
+		ref = next
+	}
+	return ""
+}
+
+func readPackedRef(commonDir, ref string) string {
+	data, err := os.ReadFile(filepath.Join(commonDir, "packed-refs"))
+	if err != nil {
+		return ""
+	}
+	for line := range strings.Lines(string(data)) {
+		line = strings.TrimSpace(line)
+		if line == "" || line[0] == '#' || line[0] == '^' {
+			continue
+		}
+		if sha, name, ok := strings.Cut(line, " "); ok && name == ref {
+			return sha
+		}
+	}
+	return ""
+}
+
+// readGitRemote returns the branch's upstream remote and its URL from the
+// repository config, falling back to "origin".
+func readGitRemote(commonDir, branch string) (string, string) {
+	f, err := os.Open(filepath.Join(commonDir, "config"))
+	if err != nil {
+		return "", ""
+	}
+	defer f.Close()
+
+	upstream := ""
+	urls := make(map[string]string)
+	section := ""
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || line[0] == '#' || line[0] == ';' {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			section = strings.TrimSpace(line[1 : len(line)-1])
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.Trim(strings.TrimSpace(value), `"`)
+
+		kind, name, _ := strings.Cut(section, " ")
+		name = strings.Trim(name, `"`)
+		switch {
+		case kind == "branch" && name == branch && branch != "" && key == "remote":
+			upstream = value
+		case kind == "remote" && key == "url":
+			urls[name] = value
+		}
+	}
+
+	if upstream == "" || upstream == "." {
+		upstream = "origin"
+	}
+	if url, ok := urls[upstream]; ok {
+		return upstream, url
+	}
+	return "", ""
+}
+
+// readCommitTime reads the committer time from a loose commit object. Packed
+// objects are not decoded; callers fall back to the reflog.
+func readCommitTime(commonDir, sha string) time.Time {
+	if len(sha) < 40 {
+		return time.Time{}
+	}
+	f, err := os.Open(filepath.Join(commonDir, "objects", sha[:2], sha[2:]))
+	if err != nil {
+		return time.Time{}
+	}
+	defer f.Close()
+
+	zr, err := zlib.NewReader(f)
+	if err != nil {
+		return time.Time{}
+	}
+	defer zr.Close()
+
+	// The header and committer line sit well within the first few KB
+	data, _ := io.ReadAll(io.LimitReader(zr, 8192))
+	if !bytes.HasPrefix(data, []byte("commit ")) {
+		return time.Time{}
+	}
+	for line := range strings.Lines(string(data)) {
+		if rest, ok := strings.CutPrefix(line, "committer "); ok {
+			return parseGitSignatureTime(rest)
+		}
+		if line == "\n" {
+			break
+		}
+	}
+	return time.Time{}
+}
+
+// readReflogTime returns the time of the last HEAD reflog entry
+func readReflogTime(gitDir string) time.Time {
+	data, err := os.ReadFile(filepath.Join(gitDir, "logs", "HEAD"))
+	if err != nil {
+		return time.Time{}
+	}
+	lines := strings.Split(strings.TrimRight(string(data), "\n"), "\n")
+	last := lines[len(lines)-1]
+	if sig, _, ok := strings.Cut(last, "\t"); ok {
+		return parseGitSignatureTime(sig)
+	}
+	return time.Time{}
+}
+
+// parseGitSignatureTime parses the "<unix> <tz>" suffix of "Name <email> 1700000000 +0000"
+func parseGitSignatureTime(sig string) time.Time {
+	fields := strings.Fields(sig)
+	if len(fields) < 2 {
+		return time.Time{}
+	}
+	sec, err := strconv.ParseInt(fields[len(fields)-2], 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+	return time.Unix(sec, 0)
+}
+
+func isWithin(root, path string) bool {
+	rel, err := filepath.Rel(root, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// gitSummary returns the repository name and branch shown alongside the process
+func gitSummary(info *model.GitInfo) (string, string) {
+	if info == nil {
+		return "", ""
+	}
+	return filepath.Base(info.Root), info.Branch
+}
@@ -0,0 +1,156 @@
+package proc
+
+import (
+	"bytes"
+	"compress/zlib"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func writeGitFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+const (
+	testCommitA = "1111111111111111111111111111111111111111"
+	testCommitB = "2222222222222222222222222222222222222222"
+)
+
+func TestReadGitInfoPackedBranchAndRemote(t *testing.T) {
+	root := t.TempDir()
+	git := filepath.Join(root, ".git")
+	writeGitFile(t, filepath.Join(git, "HEAD"), "ref: refs/heads/feature/foo\n")
+	writeGitFile(t, filepath.Join(git, "packed-refs"),
+		"# pack-refs with: peeled fully-peeled sorted\n"+
+			testCommitB+" refs/heads/feature/foo\n"+
+			testCommitA+" refs/heads/main\n")
+	writeGitFile(t, filepath.Join(git, "config"), `[core]
+	bare = false
+[remote "origin"]
+	url = https://example.com/origin.git
+[remote "upstream"]
+	url = git@example.com:team/api.git
+[branch "feature/foo"]
+	remote = upstream
+	merge = refs/heads/feature/foo
+`)
+
+	sub := filepath.Join(root, "cmd", "api")
+	if err := os.MkdirAll(sub, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	info := readGitInfo(sub, "")
+	if info == nil {
+		t.Fatal("expected git info")
+	}
+	if info.Root != root || info.Branch != "feature/foo" || info.Commit != testCommitB {
+		t.Fatalf("got root=%q branch=%q commit=%q", info.Root, info.Branch, info.Commit)
+	}
+	if info.Remote != "upstream" || info.RemoteURL != "git@example.com:team/api.git" {
+		t.Fatalf("got remote %q %q", info.Remote, info.RemoteURL)
+	}
+	if info.Detached || info.Worktree || info.Submodule {
+		t.Fatalf("unexpected flags: %+v", info)
+	}
+
+	name, branch := gitSummary(info)
+	if name != filepath.Base(root) || branch != "feature/foo" {
+		t.Fatalf("gitSummary = %q, %q", name, branch)
+	}
+}
+
+func TestReadGitInfoWorktreeDetached(t *testing.T) {
+	base := t.TempDir()
+	mainGit := filepath.Join(base, "repo", ".git")
+	writeGitFile(t, filepath.Join(mainGit, "HEAD"), "ref: refs/heads/main\n")
+	writeGitFile(t, filepath.Join(mainGit, "config"), "[remote \"origin\"]\n\turl = https://example.com/repo.git\n")
+
+	wtGit := filepath.Join(mainGit, "worktrees", "hotfix")
+	writeGitFile(t, filepath.Join(wtGit, "HEAD"), testCommitA+"\n")
+	writeGitFile(t, filepath.Join(wtGit, "commondir"), "../..\n")
+	writeGitFile(t, filepath.Join(wtGit, "logs", "HEAD"),
+		"0000000000000000000000000000000000000000 "+testCommitA+" Dev <dev@example.com> 1700000000 +0000\tcheckout: moving\n")
+
+	wt := filepath.Join(base, "hotfix")
+	writeGitFile(t, filepath.Join(wt, ".git"), "gitdir: "+wtGit+"\n")
+
+	info := readGitInfo(wt, "")
+	if info == nil {
+		t.Fatal("expected git info")
+	}
+	if !info.Worktree || !info.Detached || info.Commit != testCommitA || info.Branch != "" {
+		t.Fatalf("unexpected worktree info: %+v", info)
+	}
+	if info.RemoteURL != "https://example.com/repo.git" {
+		t.Fatalf("expected remote from common dir, got %q", info.RemoteURL)
+	}
+	if !info.HeadTime.Equal(time.Unix(1700000000, 0)) {
+		t.Fatalf("expected reflog time, got %v", info.HeadTime)
+	}
+}
+
+func TestReadGitInfoLooseCommitAndStaleBinary(t *testing.T) {
+	root := t.TempDir()
+	git := filepath.Join(root, ".git")
+	writeGitFile(t, filepath.Join(git, "HEAD"), "ref: refs/heads/main\n")
+	writeGitFile(t, filepath.Join(git, "refs", "heads", "main"), testCommitA+"\n")
+
+	body := "tree 4b825dc642cb6eb9a060e54bf8d69288fbee4904\n" +
+		"author Dev <dev@example.com> 1700000000 +0000\n" +
+		"committer Dev <dev@example.com> 1700003600 +0100\n\nmsg\n"
+	var buf bytes.Buffer
+	zw := zlib.NewWriter(&buf)
+	zw.Write([]byte("commit " + strconv.Itoa(len(body)) + "\x00" + body))
+	zw.Close()
+	writeGitFile(t, filepath.Join(git, "objects", testCommitA[:2], testCommitA[2:]), buf.String())
+
+	exe := filepath.Join(root, "bin", "api")
+	writeGitFile(t, exe, "binary")
+	old := time.Unix(1600000000, 0)
+	if err := os.Chtimes(exe, old, old); err != nil {
+		t.Fatal(err)
+	}
+
+	info := readGitInfo(root, exe)
+	if !info.HeadTime.Equal(time.Unix(1700003600, 0)) {
+		t.Fatalf("expected committer time, got %v", info.HeadTime)
+	}
+	if !info.BinaryInRepo || info.BinaryNewer {
+		t.Fatalf("expected stale in-repo binary, got %+v", info)
+	}
+
+	if outside := readGitInfo(root, "/usr/bin/true"); outside.BinaryInRepo {
+		t.Fatal("binary outside the repo should not be flagged")
+	}
+}
+
+func TestReadGitInfoSubmodule(t *testing.T) {
+	base := t.TempDir()
+	modGit := filepath.Join(base, "parent", ".git", "modules", "lib")
+	writeGitFile(t, filepath.Join(modGit, "HEAD"), testCommitB+"\n")
+	lib := filepath.Join(base, "parent", "lib")
+	writeGitFile(t, filepath.Join(lib, ".git"), "gitdir: ../.git/modules/lib\n")
+
+	info := readGitInfo(lib, "")
+	if info == nil || !info.Submodule || info.Worktree || info.Root != lib {
+		t.Fatalf("unexpected submodule info: %+v", info)
+	}
+}
+
+func TestReadGitInfoUnknownCwd(t *testing.T) {
+	for _, cwd := range []string{"", "unknown", "invalid"} {
+		if info := readGitInfo(cwd, ""); info != nil {
+			t.Fatalf("expected nil for cwd %q, got %+v", cwd, info)
+		}
+	}
+}
@@ -0,0 +1,272 @@
+package proc
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pranshuparmar/witr/internal/cri"
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+// kubeletPodsDir holds one directory per pod, named by pod UID
+const kubeletPodsDir = "/var/lib/kubelet/pods"
+
+// resolveKubernetes identifies the pod behind a kubepods cgroup, asking the
+// node's CRI runtime for the container and its pod sandbox and falling back
+// to what kubelet keeps on disk for the pod. Returns nil if the cgroup is not
+// a pod's.
+func resolveKubernetes(cgroup string) *model.KubernetesInfo {
+	k := parseKubepodsCgroup(cgroup)
+	if k == nil {
+		return nil
+	}
+	if !resolvePodFromCRI(k) {
+		resolvePodFromKubelet("/", k)
+		if k.Container == "" && k.ContainerID != "" {
+			k.Container = resolveContainerName(k.ContainerID, "crictl")
+		}
+	}
+	return k
+}
+
+// parseKubepodsCgroup reads the pod UID, QoS class and container ID from a
+// cgroup path as laid out by either kubelet cgroup driver:
+//
+//	/kubepods.slice/kubepods-burstable.slice/kubepods-burstable-pod<uid>.slice/cri-containerd-<id>.scope
+//	/kubepods/besteffort/pod<uid>/<id>
+//
+// Guaranteed pods sit directly under kubepods without a QoS level.
+func parseKubepodsCgroup(cgroup string) *model.KubernetesInfo {
+	for line := range strings.Lines(cgroup) {
+		if !strings.Contains(line, "kubepods") {
+			continue
+		}
+		k := &model.KubernetesInfo{QoSClass: "Guaranteed"}
+		for seg := range strings.SplitSeq(strings.TrimSpace(line), "/") {
+			seg = strings.TrimSuffix(seg, ".slice")
+			switch {
+			case strings.Contains(seg, "besteffort"):
+				k.QoSClass = "BestEffort"
+			case strings.Contains(seg, "burstable"):
+				k.QoSClass = "Burstable"
+			}
+			if i := strings.LastIndex(seg, "pod"); i >= 0 && (i == 0 || seg[i-1] == '-') {
+				// The systemd driver escapes the UID's dashes as underscores
+				if uid := strings.ReplaceAll(seg[i+3:], "_", "-"); len(uid) == 36 {
+					k.PodUID = uid
+				}
+			}
+		}
+		if k.PodUID == "" {
+			continue
+		}
+		k.ContainerID = findLongHexID(line)
+		return k
+	}
+	return nil
+}
+
+// resolvePodFromCRI fills in the pod from the container's labels and its
+// sandbox's metadata, trying each runtime socket on the node
+func resolvePodFromCRI(k *model.KubernetesInfo) bool {
+	if k.ContainerID == "" {
+		return false
+	}
+	clients, err := cri.Connect()
+	if err != nil {
+		return false
+	}
+	for _, c := range clients {
+		ctx, cancel := context.WithTimeout(context.Background(), cri.Timeout)
+		ctr, err := c.Container(ctx, k.ContainerID)
+		if err != nil || ctr == nil {
+			cancel()
+			continue
+		}
+		k.Container = ctr.Name
+		k.Image = ctr.Image
+		k.Restarts = ctr.Attempt
+		k.Pod = ctr.Labels[cri.LabelPodName]
+		k.Namespace = ctr.Labels[cri.LabelPodNamespace]
+		if k.Container == "" {
+			k.Container = ctr.Labels[cri.LabelContainerName]
+		}
+
+		var labels, annotations map[string]string
+		if pod, err := c.PodSandbox(ctx, ctr.PodSandboxID); err == nil {
+			k.Pod = pod.Name
+			k.Namespace = pod.Namespace
+			labels, annotations = pod.Labels, pod.Annotations
+		}
+		cancel()
+		k.OwnerKind, k.OwnerName = podOwner(k.Pod, labels, annotations)
+		return true
+	}
+	return false
+}
+
+// resolvePodFromKubelet reads the pod's name from the hosts file kubelet
+// writes for it, its namespace from the service account volume and its
+// container from the per-container directories, when there is only one. The
+// hosts file records the pod's hostname, which differs from its name when
+// spec.hostname is set.
+func resolvePodFromKubelet(root string, k *model.KubernetesInfo) {
+	dir := filepath.Join(root, kubeletPodsDir, k.PodUID)
+	if _, err := os.Stat(dir); err != nil {
+		return
+	}
+
+	if data, err := os.ReadFile(filepath.Join(dir, "etc-hosts")); err == nil {
+		k.Pod = podHostname(string(data))
+	}
+
+	matches, _ := filepath.Glob(filepath.Join(dir, "volumes", "kubernetes.io~projected", "*", "namespace"))
+	for _, m := range matches {
+		if data, err := os.ReadFile(m); err == nil {
+			k.Namespace = strings.TrimSpace(string(data))
+			break
+		}
+	}
+
+	if entries, err := os.ReadDir(filepath.Join(dir, "containers")); err == nil && len(entries) == 1 {
+		k.Container = entries[0].Name()
+	}
+
+	k.OwnerKind, k.OwnerName = podOwner(k.Pod, nil, nil)
+}
+
+// podHostname finds the pod's own entry in the hosts file kubelet writes for
+// it: the last line before any HostAliases entries, "IP [fqdn] hostname",
+// following the localhost and ip6-* lines. The hostname is the pod name unless
+// the pod sets spec.hostname. Host network pods get a copy of the node's
+// hosts file and yield "".
+func podHostname(hosts string) string {
+	name := ""
+	for line := range strings.Lines(hosts) {
+		switch {
+		case strings.Contains(line, "(host network)"):
+			return ""
+		case strings.HasPrefix(line, "# Entries added by HostAliases"):
+			return name
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 2 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		if ip := net.ParseIP(fields[0]); ip == nil || ip.IsLoopback() || ip.IsMulticast() || strings.HasPrefix(fields[0], "fe00::") {
+			continue
+		}
+		name = fields[len(fields)-1]
+	}
+	return name
+}
+
+// podOwner names the controller that created a pod from the labels each
+// controller stamps on its pods and the names it derives for them. Without
+// labels only the unmistakable Deployment naming, <deployment>-<hash>-<id>,
+// is recognized.
+func podOwner(pod string, labels, annotations map[string]string) (kind, name string) {
+	if pod == "" {
+		return "", ""
+	}
+	switch annotations["kubernetes.io/config.source"] {
+	case "file", "http":
+		// Static pods are mirrored into the API owned by their node
+		return "Node", ""
+	}
+
+	base := trimNameSuffix(pod)
+	switch {
+	case labels == nil:
+		if base != pod && isGeneratedSuffix(pod[len(base)+1:], 5, 5) {
+			if deployment := trimNameSuffix(base); deployment != base && isGeneratedSuffix(base[len(deployment)+1:], 6, 10) {
+				return "Deployment", deployment
+			}
+		}
+	case labels["pod-template-hash"] != "":
+		if deployment, ok := strings.CutSuffix(base, "-"+labels["pod-template-hash"]); ok {
+			return "Deployment", deployment
+		}
+		return "ReplicaSet", base
+	case labels["statefulset.kubernetes.io/pod-name"] != "":
+		return "StatefulSet", base
+	case labels["controller-revision-hash"] != "" && labels["pod-template-generation"] != "":
+		return "DaemonSet", base
+	default:
+		job := labels["batch.kubernetes.io/job-name"]
+		if job == "" {
+			job = labels["job-name"]
+		}
+		if job == "" {
+			break
+		}
+		// CronJobs name each Job after the scheduled minute since the epoch
+		if cron := trimNameSuffix(job); cron != job && len(job)-len(cron)-1 >= 8 && isDigits(job[len(cron)+1:]) {
+			return "CronJob", cron
+		}
+		return "Job", job
+	}
+	return "", ""
+}
+
+// trimNameSuffix drops the last dash-separated part of a generated name
+func trimNameSuffix(name string) string {
+	if i := strings.LastIndexByte(name, '-'); i > 0 {
+		return name[:i]
+	}
+	return name
+}
+
+// isGeneratedSuffix reports whether s could come from Kubernetes' random
+// name generator, which avoids vowels and look-alike characters
+func isGeneratedSuffix(s string, minLen, maxLen int) bool {
+	if len(s) < minLen || len(s) > maxLen {
+		return false
+	}
+	for _, c := range s {
+		if !strings.ContainsRune("bcdfghjklmnpqrstvwxz2456789", c) {
+			return false
+		}
+	}
+	return true
+}
+
+func isDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// kubernetesLabel formats a pod as "Deployment default/api → pod
+// api-7f9c5d4b8-x2x9z → container app", leaving out what is unknown
+func kubernetesLabel(k *model.KubernetesInfo) string {
+	if k.Pod == "" {
+		return ""
+	}
+	qualify := func(name string) string {
+		if k.Namespace == "" {
+			return name
+		}
+		return k.Namespace + "/" + name
+	}
+
+	var parts []string
+	switch {
+	case k.OwnerKind == "Node":
+		parts = append(parts, "static pod "+qualify(k.Pod))
+	case k.OwnerKind != "" && k.OwnerName != "":
+		parts = append(parts, k.OwnerKind+" "+qualify(k.OwnerName), "pod "+k.Pod)
+	default:
+		parts = append(parts, "pod "+qualify(k.Pod))
+	}
+	if k.Container != "" {
+		parts = append(parts, "container "+k.Container)
+	}
+	return strings.Join(parts, " → ")
+}
@@ -0,0 +1,141 @@
+package proc
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+const (
+	testPodUID = "1b4e28ba-2fa1-11d2-883f-0016d3cca427"
+	testCtrID  = "9b1d3c0e5f7a2b4c6d8e0f1a3b5c7d9e1f3a5b7c9d1e3f5a7b9c1d3e5f7a9b1d"
+)
+
+func TestParseKubepodsCgroup(t *testing.T) {
+	escaped := strings.ReplaceAll(testPodUID, "-", "_")
+	tests := []struct {
+		name   string
+		cgroup string
+		qos    string
+	}{
+		{"systemd burstable", "0::/kubepods.slice/kubepods-burstable.slice/kubepods-burstable-pod" + escaped + ".slice/cri-containerd-" + testCtrID + ".scope\n", "Burstable"},
+		{"systemd guaranteed", "0::/kubepods.slice/kubepods-pod" + escaped + ".slice/crio-" + testCtrID + ".scope\n", "Guaranteed"},
+		{"cgroupfs besteffort", "12:memory:/kubepods/besteffort/pod" + testPodUID + "/" + testCtrID + "\n", "BestEffort"},
+		{"cgroup v1 mixed", "1:name=systemd:/user.slice\n4:cpu:/kubepods/burstable/pod" + testPodUID + "/" + testCtrID + "\n", "Burstable"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k := parseKubepodsCgroup(tt.cgroup)
+			if k == nil {
+				t.Fatal("parseKubepodsCgroup() = nil")
+			}
+			if k.PodUID != testPodUID || k.ContainerID != testCtrID || k.QoSClass != tt.qos {
+				t.Errorf("got uid %q, id %q, qos %q; want qos %q", k.PodUID, k.ContainerID, k.QoSClass, tt.qos)
+			}
+		})
+	}
+
+	if k := parseKubepodsCgroup("0::/system.slice/docker-" + testCtrID + ".scope\n"); k != nil {
+		t.Errorf("non-pod cgroup parsed as %+v", k)
+	}
+}
+
+func TestPodOwner(t *testing.T) {
+	tests := []struct {
+		name        string
+		pod         string
+		labels      map[string]string
+		annotations map[string]string
+		kind, owner string
+	}{
+		{"deployment", "api-7f9c5d4b8-x2x9z", map[string]string{"pod-template-hash": "7f9c5d4b8"}, nil, "Deployment", "api"},
+		{"bare replicaset", "web-x2x9z", map[string]string{"pod-template-hash": "6d5f8"}, nil, "ReplicaSet", "web"},
+		{"statefulset", "db-postgres-2", map[string]string{"controller-revision-hash": "db-postgres-5c9d", "statefulset.kubernetes.io/pod-name": "db-postgres-2"}, nil, "StatefulSet", "db-postgres"},
+		{"daemonset", "fluentd-k8qzt", map[string]string{"controller-revision-hash": "7c4b9", "pod-template-generation": "3"}, nil, "DaemonSet", "fluentd"},
+		{"job", "migrate-q7wpl", map[string]string{"batch.kubernetes.io/job-name": "migrate"}, nil, "Job", "migrate"},
+		{"cronjob", "backup-28312345-q7wpl", map[string]string{"job-name": "backup-28312345"}, nil, "CronJob", "backup"},
+		{"static pod", "kube-apiserver-node1", map[string]string{"tier": "control-plane"}, map[string]string{"kubernetes.io/config.source": "file"}, "Node", ""},
+		{"bare pod", "debug", map[string]string{"run": "debug"}, nil, "", ""},
+		{"name only deployment", "api-7f9c5d4b8-x2x9z", nil, nil, "Deployment", "api"},
+		{"name only other", "web-0", nil, nil, "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kind, owner := podOwner(tt.pod, tt.labels, tt.annotations)
+			if kind != tt.kind || owner != tt.owner {
+				t.Errorf("podOwner() = %q %q, want %q %q", kind, owner, tt.kind, tt.owner)
+			}
+		})
+	}
+}
+
+func TestResolvePodFromKubelet(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, kubeletPodsDir, testPodUID)
+	files := map[string]string{
+		"etc-hosts": "# Kubernetes-managed hosts file.\n127.0.0.1\tlocalhost\n::1\tlocalhost ip6-localhost\n10.244.1.17\tapi-7f9c5d4b8-x2x9z\n",
+		"volumes/kubernetes.io~projected/kube-api-access-8xk2p/namespace": "shop\n",
+		"containers/app/4c1e9a2f": "",
+	}
+	for rel, content := range files {
+		path := filepath.Join(dir, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	k := &model.KubernetesInfo{PodUID: testPodUID, QoSClass: "Burstable"}
+	resolvePodFromKubelet(root, k)
+	if got, want := kubernetesLabel(k), "Deployment shop/api → pod api-7f9c5d4b8-x2x9z → container app"; got != want {
+		t.Errorf("label = %q, want %q", got, want)
+	}
+}
+
+func TestPodHostname(t *testing.T) {
+	const header = "# Kubernetes-managed hosts file.\n127.0.0.1\tlocalhost\n::1\tlocalhost ip6-localhost ip6-loopback\n" +
+		"fe00::0\tip6-localnet\nfe00::0\tip6-mcastprefix\nfe00::1\tip6-allnodes\nfe00::2\tip6-allrouters\n"
+	tests := []struct {
+		name  string
+		hosts string
+		want  string
+	}{
+		{"plain", header + "10.244.1.17\tapi-7f9c5d4b8-x2x9z\n", "api-7f9c5d4b8-x2x9z"},
+		{"subdomain", header + "10.244.1.18\tweb-0.nginx.default.svc.cluster.local\tweb-0\n", "web-0"},
+		{"host aliases", header + "10.244.1.17\tapi-7f9c5d4b8-x2x9z\n\n# Entries added by HostAliases.\n10.0.0.9\tfoo.local\tbar.local\n", "api-7f9c5d4b8-x2x9z"},
+		{"no pod ip", header, ""},
+		{"host network", "# Kubernetes-managed hosts file (host network).\n127.0.0.1 localhost\n192.168.1.5 node1\n", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := podHostname(tt.hosts); got != tt.want {
+				t.Errorf("podHostname() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKubernetesLabel(t *testing.T) {
+	tests := []struct {
+		name string
+		k    model.KubernetesInfo
+		want string
+	}{
+		{"owned", model.KubernetesInfo{Namespace: "default", Pod: "api-7f9c", Container: "app", OwnerKind: "Deployment", OwnerName: "api"}, "Deployment default/api → pod api-7f9c → container app"},
+		{"bare", model.KubernetesInfo{Namespace: "default", Pod: "debug", Container: "shell"}, "pod default/debug → container shell"},
+		{"static", model.KubernetesInfo{Namespace: "kube-system", Pod: "etcd-node1", Container: "etcd", OwnerKind: "Node"}, "static pod kube-system/etcd-node1 → container etcd"},
+		{"unknown", model.KubernetesInfo{PodUID: testPodUID}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := kubernetesLabel(&tt.k); got != tt.want {
+				t.Errorf("kubernetesLabel() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
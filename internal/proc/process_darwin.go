@@ -67,7 +67,6 @@ func ReadProcess(pid int) (model.Process, error) {
 
 	// Health status
 	health := "healthy"
-	forked := "unknown"
 
 	switch state {
 	case "Z":
@@ -76,12 +75,8 @@ func ReadProcess(pid int) (model.Process, error) {
 		health = "stopped"
 	}
 
-	// Fork detection
-	if ppid != 1 && comm != "launchd" {
-		forked = "forked"
-	} else {
-		forked = "not-forked"
-	}
+	// Session, process group and controlling terminal
+	session := readSession(pid, ppid)
 
 	// Get user from UID
 	user := readUserByUID(uid)
@@ -97,7 +92,8 @@ func ReadProcess(pid int) (model.Process, error) {
 	service := detectLaunchdService(pid)
 
 	// Git repo/branch detection
-	gitRepo, gitBranch := detectGitInfo(cwd)
+	gitInfo := readGitInfo(cwd, "")
+	gitRepo, gitBranch := gitSummary(gitInfo)
 
 	// Get listening ports for this process
 	inodes := socketsForPID(pid)
@@ -129,12 +125,13 @@ func ReadProcess(pid int) (model.Process, error) {
 		WorkingDir:     cwd,
 		GitRepo:        gitRepo,
 		GitBranch:      gitBranch,
+		Git:            gitInfo,
 		Container:      container,
 		Service:        service,
 		ListeningPorts: ports,
 		BindAddresses:  addrs,
 		Health:         health,
-		Forked:         forked,
+		Session:        session,
 		Env:            env,
 		ExeDeleted:     isBinaryDeleted(pid),
 	}, nil
@@ -350,45 +347,6 @@ func detectLaunchdService(pid int) string {
 	return ""
 }
 
-func detectGitInfo(cwd string) (string, string) {
-	if cwd == "unknown" || cwd == "" {
-		return "", ""
-	}
-
-	searchDir := cwd
-	for searchDir != "/" && searchDir != "." && searchDir != "" {
-		gitDir := searchDir + "/.git"
-		if fi, err := os.Stat(gitDir); err == nil && fi.IsDir() {
-			// Repo name is the base dir
-			parts := strings.Split(strings.TrimRight(searchDir, "/"), "/")
-			gitRepo := parts[len(parts)-1]
-
-			// Try to read HEAD for branch
-			gitBranch := ""
-			headFile := gitDir + "/HEAD"
-			if head, err := os.ReadFile(headFile); err == nil {
-				headStr := strings.TrimSpace(string(head))
-				if strings.HasPrefix(headStr, "ref: ") {
-					ref := strings.TrimPrefix(headStr, "ref: ")
-					refParts := strings.Split(ref, "/")
-					gitBranch = refParts[len(refParts)-1]
-				}
-			}
-
-			return gitRepo, gitBranch
-		}
-
-		// Move up one directory
-		idx := strings.LastIndex(searchDir, "/")
-		if idx <= 0 {
-			break
-		}
-		searchDir = searchDir[:idx]
-	}
-
-	return "", ""
-}
-
 // buildEnvForPS returns environment variables with LC_ALL=C and TZ=UTC,
 // removing any existing LC_ALL or TZ to ensure consistent output format.
 func buildEnvForPS() []string {
@@ -429,39 +387,3 @@ func checkResourceUsage(pid int, currentHealth string) string {
 
 	return currentHealth
 }
-
-func resolveDockerProxyContainer(cmdline string) string {
-	var containerIP string
-	parts := strings.Fields(cmdline)
-	for i, part := range parts {
-		if part == "-container-ip" && i+1 < len(parts) {
-			containerIP = parts[i+1]
-			break
-		}
-	}
-	if containerIP == "" {
-		return ""
-	}
-
-	out, err := exec.Command("docker", "network", "inspect", "bridge",
-		"--format", "{{range .Containers}}{{.Name}}:{{.IPv4Address}}{{\"\\n\"}}{{end}}").Output()
-	if err != nil {
-		return ""
-	}
-
-	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
-		if line == "" {
-			continue
-		}
-		colonIdx := strings.Index(line, ":")
-		if colonIdx == -1 {
-			continue
-		}
-		name := line[:colonIdx]
-		ip := strings.Split(line[colonIdx+1:], "/")[0]
-		if ip == containerIP {
-			return "target: " + name
-		}
-	}
-	return ""
-}
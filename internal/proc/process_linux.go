@@ -5,7 +5,7 @@ package proc
 import (
 	"fmt"
 	"os"
-	"os/exec"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
@@ -78,16 +78,34 @@ func ReadProcess(pid int) (model.Process, error) {
 
 	// Container detection
 	container := ""
+	var containerInfo *model.ContainerInfo
+	var kubernetes *model.KubernetesInfo
 	cgroupFile := fmt.Sprintf("/proc/%d/cgroup", pid)
 	if cgroupData, err := os.ReadFile(cgroupFile); err == nil {
 		cgroupStr := string(cgroupData)
 		var containerID string
 		switch {
+		// Pods are checked first: their cgroups also name the runtime
+		// (docker-<id>.scope, cri-containerd-<id>.scope)
+		case strings.Contains(cgroupStr, "kubepods"):
+			container = "kubernetes"
+			if k := resolveKubernetes(cgroupStr); k != nil {
+				kubernetes = k
+				containerID = k.ContainerID
+				if label := kubernetesLabel(k); label != "" {
+					container = label
+				} else if len(containerID) > 12 {
+					container = "k8s (" + containerID[:12] + ")"
+				}
+			}
+
 		case strings.Contains(cgroupStr, "docker"):
 			container = "docker"
 			containerID = extractContainerID(cgroupStr, "docker-", "docker/")
 			if containerID != "" {
-				if name := resolveContainerName(containerID, "docker"); name != "" {
+				name, info := resolveContainer(containerID, "docker")
+				containerInfo = info
+				if name != "" {
 					container = name
 				} else {
 					if len(containerID) > 12 {
@@ -100,7 +118,9 @@ func ReadProcess(pid int) (model.Process, error) {
 			container = "podman"
 			containerID = extractContainerID(cgroupStr, "libpod-", "libpod/")
 			if containerID != "" {
-				if name := resolveContainerName(containerID, "podman"); name != "" {
+				name, info := resolveContainer(containerID, "podman")
+				containerInfo = info
+				if name != "" {
 					container = name
 				} else {
 					if len(containerID) > 12 {
@@ -109,17 +129,6 @@ func ReadProcess(pid int) (model.Process, error) {
 				}
 			}
 
-		case strings.Contains(cgroupStr, "kubepods"):
-			container = "kubernetes"
-			if id := findLongHexID(cgroupStr); id != "" {
-				containerID = id
-				if name := resolveContainerName(containerID, "crictl"); name != "" {
-					container = "k8s: " + name
-				} else {
-					container = "k8s (" + containerID[:12] + ")"
-				}
-			}
-
 		case strings.Contains(cgroupStr, "containerd"):
 			container = "containerd"
 			if id := findLongHexID(cgroupStr); id != "" {
@@ -144,55 +153,19 @@ func ReadProcess(pid int) (model.Process, error) {
 		}
 	}
 
-	// Service detection (try systemctl show for this PID)
-	service := ""
-	svcOut, err := exec.Command("systemctl", "status", fmt.Sprintf("%d", pid)).CombinedOutput()
-	if err == nil && strings.Contains(string(svcOut), "Loaded: loaded") {
-		// Try to extract service name from output
-		for line := range strings.Lines(string(svcOut)) {
-			if strings.HasPrefix(line, "Loaded:") && strings.Contains(line, ".service") {
-				parts := strings.Fields(line)
-				for _, part := range parts {
-					if strings.HasSuffix(part, ".service") {
-						service = part
-						break
-					}
-				}
-			}
-		}
-	}
+	// Service detection (the systemd unit managing this PID)
+	service := resolveServiceForPID(pid)
 
-	// Git repo/branch detection (walk up to find .git)
-	gitRepo := ""
-	gitBranch := ""
-	if cwd != "unknown" {
-		searchDir := cwd
-		for searchDir != "/" && searchDir != "." && searchDir != "" {
-			gitDir := searchDir + "/.git"
-			if fi, err := os.Stat(gitDir); err == nil && fi.IsDir() {
-				// Repo name is the base dir
-				parts := strings.Split(strings.TrimRight(searchDir, "/"), "/")
-				gitRepo = parts[len(parts)-1]
-				// Try to read HEAD for branch
-				headFile := gitDir + "/HEAD"
-				if head, err := os.ReadFile(headFile); err == nil {
-					headStr := strings.TrimSpace(string(head))
-					if strings.HasPrefix(headStr, "ref: ") {
-						ref := strings.TrimPrefix(headStr, "ref: ")
-						refParts := strings.Split(ref, "/")
-						gitBranch = refParts[len(refParts)-1]
-					}
-				}
-				break
-			}
-			// Move up one directory
-			idx := strings.LastIndex(searchDir, "/")
-			if idx <= 0 {
-				break
-			}
-			searchDir = searchDir[:idx]
-		}
+	exe, _ := os.Readlink(fmt.Sprintf("/proc/%d/exe", pid))
+	exe = strings.TrimSuffix(exe, " (deleted)")
+
+	// Git checkout the process runs from; a replaced binary says nothing about HEAD
+	gitExe := exe
+	if isBinaryDeleted(pid) {
+		gitExe = ""
 	}
+	gitInfo := readGitInfo(cwd, gitExe)
+	gitRepo, gitBranch := gitSummary(gitInfo)
 
 	// stat format is evil, command is inside ()
 	raw := string(stat)
@@ -209,13 +182,8 @@ func ReadProcess(pid int) (model.Process, error) {
 	state := processState(fields)
 	startTicks, _ := strconv.ParseInt(fields[19], 10, 64)
 
-	// Fork detection: if ppid != 1 and not systemd, likely forked; also check for vfork/fork/clone flags if possible
-	var forked string
-	if ppid != 1 && comm != "systemd" {
-		forked = "forked"
-	} else {
-		forked = "not-forked"
-	}
+	// Session, process group and controlling terminal tell us how it was detached
+	session := readSession(pid, ppid, fields)
 
 	startedAt := bootTime().Add(time.Duration(startTicks) * time.Second / ticksPerSecond())
 
@@ -245,6 +213,13 @@ func ReadProcess(pid int) (model.Process, error) {
 
 	user := readUser(pid)
 
+	// ptrace attachment (debuggers, tracers, profilers)
+	tracerPID := readTracerPID(pid)
+	tracerUser := ""
+	if tracerPID > 0 {
+		tracerUser = readUser(tracerPID)
+	}
+
 	sockets, _ := readSockets()
 	inodes := socketsForPID(pid)
 
@@ -288,6 +263,8 @@ func ReadProcess(pid int) (model.Process, error) {
 		cmdline = strings.TrimSpace(cmd)
 	}
 
+	kernelThread := isKernelThread("/proc", ppid, fields)
+
 	if comm == "docker-proxy" && container == "" {
 		container = resolveDockerProxyContainer(cmdline)
 	}
@@ -297,64 +274,76 @@ func ReadProcess(pid int) (model.Process, error) {
 		PPID:           ppid,
 		Command:        comm,
 		Cmdline:        cmdline,
+		Exe:            exe,
 		StartedAt:      startedAt,
 		User:           user,
 		WorkingDir:     cwd,
 		GitRepo:        gitRepo,
 		GitBranch:      gitBranch,
+		Git:            gitInfo,
 		Container:      container,
+		ContainerInfo:  containerInfo,
+		Kubernetes:     kubernetes,
 		Service:        service,
 		ListeningPorts: ports,
 		BindAddresses:  addrs,
 		Health:         health,
-		Forked:         forked,
+		Session:        session,
+		TracerPID:      tracerPID,
+		TracerUser:     tracerUser,
+		KernelThread:   kernelThread,
 		Env:            env,
 		ExeDeleted:     isBinaryDeleted(pid),
 	}, nil
 }
 
-func isBinaryDeleted(pid int) bool {
-	exePath, err := os.Readlink(fmt.Sprintf("/proc/%d/exe", pid))
+// pfKthread is PF_KTHREAD from include/linux/sched.h
+const pfKthread = 0x00200000
+
+// isKernelThread reports whether a process is a kernel thread from the
+// PF_KTHREAD bit in its stat flags. PID 2 and its children are only kernel
+// threads in the initial PID namespace, so a kthreadd parent is trusted only
+// when the flags are unreadable and /proc/2 is itself the kernel's kthreadd.
+func isKernelThread(procRoot string, ppid int, fields []string) bool {
+	if flags, ok := statFlags(fields); ok {
+		return flags&pfKthread != 0
+	}
+	if ppid != 2 {
+		return false
+	}
+	comm, err := os.ReadFile(filepath.Join(procRoot, "2", "comm"))
+	if err != nil || strings.TrimSpace(string(comm)) != "kthreadd" {
+		return false
+	}
+	stat, err := os.ReadFile(filepath.Join(procRoot, "2", "stat"))
 	if err != nil {
 		return false
 	}
-	return strings.HasSuffix(exePath, " (deleted)")
+	raw := string(stat)
+	close := strings.LastIndex(raw, ")")
+	if close == -1 || close+2 > len(raw) {
+		return false
+	}
+	flags, ok := statFlags(strings.Fields(raw[close+2:]))
+	return ok && flags&pfKthread != 0
 }
 
-func resolveDockerProxyContainer(cmdline string) string {
-	var containerIP string
-	parts := strings.Fields(cmdline)
-	for i, part := range parts {
-		if part == "-container-ip" && i+1 < len(parts) {
-			containerIP = parts[i+1]
-			break
-		}
-	}
-	if containerIP == "" {
-		return ""
+// statFlags parses the flags field of /proc/<pid>/stat from the fields
+// following the command name
+func statFlags(fields []string) (uint64, bool) {
+	if len(fields) <= 6 {
+		return 0, false
 	}
+	flags, err := strconv.ParseUint(fields[6], 10, 64)
+	return flags, err == nil
+}
 
-	out, err := exec.Command("docker", "network", "inspect", "bridge",
-		"--format", "{{range .Containers}}{{.Name}}:{{.IPv4Address}}{{\"\\n\"}}{{end}}").Output()
+func isBinaryDeleted(pid int) bool {
+	exePath, err := os.Readlink(fmt.Sprintf("/proc/%d/exe", pid))
 	if err != nil {
-		return ""
-	}
-
-	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
-		if line == "" {
-			continue
-		}
-		colonIdx := strings.Index(line, ":")
-		if colonIdx == -1 {
-			continue
-		}
-		name := line[:colonIdx]
-		ip := strings.Split(line[colonIdx+1:], "/")[0]
-		if ip == containerIP {
-			return "target: " + name
-		}
+		return false
 	}
-	return ""
+	return strings.HasSuffix(exePath, " (deleted)")
 }
 
 // The kernel emits the state immediately after the command, so fields[0] always carries it.
@@ -0,0 +1,60 @@
+//go:build linux
+
+package proc
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestIsKernelThread(t *testing.T) {
+	// Fields after the command: state ppid pgrp session tty_nr tpgid flags
+	stat := func(ppid, flags string) []string {
+		return strings.Fields("S " + ppid + " 0 0 0 -1 " + flags + " 0 0")
+	}
+	const kthread, user = "2129984", "4194560" // PF_KTHREAD set / a userland process's flags
+
+	// A container's PID namespace, where pid 2 is an ordinary process
+	container := fakePID2(t, "bash", "2 (bash) S 1 2 2 0 -1 "+user+" 0 0\n")
+	host := fakePID2(t, "kthreadd", "2 (kthreadd) S 0 0 0 0 -1 "+kthread+" 0 0\n")
+
+	tests := []struct {
+		name   string
+		root   string
+		ppid   int
+		fields []string
+		want   bool
+	}{
+		{"kernel thread", host, 2, stat("2", kthread), true},
+		{"kthreadd", host, 0, stat("0", kthread), true},
+		{"pid 2 in a container", container, 1, stat("1", user), false},
+		{"child of pid 2 in a container", container, 2, stat("2", user), false},
+		{"no flags under kthreadd", host, 2, strings.Fields("S 2"), true},
+		{"no flags under container pid 2", container, 2, strings.Fields("S 2"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isKernelThread(tt.root, tt.ppid, tt.fields); got != tt.want {
+				t.Errorf("isKernelThread() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// fakePID2 builds a procfs root holding only /proc/2/comm and /proc/2/stat
+func fakePID2(t *testing.T, comm, stat string) string {
+	t.Helper()
+	root := t.TempDir()
+	dir := filepath.Join(root, "2")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range map[string]string{"comm": comm + "\n", "stat": stat} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
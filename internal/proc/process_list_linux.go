@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
@@ -89,11 +90,17 @@ func ListProcesses() ([]model.Process, error) {
 // for child/descendant discovery. We avoid full ReadProcess calls to keep
 // this path fast and to reduce permission-sensitive reads.
 func listProcessSnapshot() ([]model.Process, error) {
-	entries, err := os.ReadDir("/proc")
+	return readProcessSnapshot("/proc")
+}
+
+// readProcessSnapshot builds the snapshot from the stat files under a procfs root
+func readProcessSnapshot(procRoot string) ([]model.Process, error) {
+	entries, err := os.ReadDir(procRoot)
 	if err != nil {
-		return nil, fmt.Errorf("read /proc: %w", err)
+		return nil, fmt.Errorf("read %s: %w", procRoot, err)
 	}
 
+	boot := bootTime()
 	processes := make([]model.Process, 0, len(entries))
 	for _, entry := range entries {
 		if !entry.IsDir() {
@@ -105,13 +112,12 @@ func listProcessSnapshot() ([]model.Process, error) {
 			continue
 		}
 
-		statPath := fmt.Sprintf("/proc/%d/stat", pid)
-		stat, err := os.ReadFile(statPath)
+		stat, err := os.ReadFile(filepath.Join(procRoot, entry.Name(), "stat"))
 		if err != nil {
 			continue
 		}
 
-		proc, err := parseStatSnapshot(pid, stat)
+		proc, err := parseStatSnapshot(pid, stat, boot)
 		if err != nil {
 			continue
 		}
@@ -122,7 +128,7 @@ func listProcessSnapshot() ([]model.Process, error) {
 	return processes, nil
 }
 
-func parseStatSnapshot(pid int, stat []byte) (model.Process, error) {
+func parseStatSnapshot(pid int, stat []byte, boot time.Time) (model.Process, error) {
 	raw := string(stat)
 	open := strings.Index(raw, "(")
 	close := strings.LastIndex(raw, ")")
@@ -141,9 +147,20 @@ func parseStatSnapshot(pid int, stat []byte) (model.Process, error) {
 		return model.Process{}, fmt.Errorf("invalid ppid")
 	}
 
-	return model.Process{
+	proc := model.Process{
 		PID:     pid,
 		PPID:    ppid,
 		Command: comm,
-	}, nil
+	}
+
+	// Zombies are reported so callers can tell which children a parent has not reaped
+	if processState(fields) == "Z" {
+		proc.Health = "zombie"
+	}
+	if len(fields) > 19 {
+		startTicks, _ := strconv.ParseInt(fields[19], 10, 64)
+		proc.StartedAt = boot.Add(time.Duration(startTicks) * time.Second / ticksPerSecond())
+	}
+
+	return proc, nil
 }
@@ -26,7 +26,8 @@ func ReadProcess(pid int) (model.Process, error) {
 	ports, addrs := GetListeningPortsForPID(pid)
 	serviceName := detectWindowsServiceSource(pid)
 	container := detectContainer(info.CommandLine)
-	gitRepo, gitBranch := detectGitInfo(info.Cwd)
+	gitInfo := readGitInfo(info.Cwd, info.Exe)
+	gitRepo, gitBranch := gitSummary(gitInfo)
 
 	return model.Process{
 		PID:            pid,
@@ -39,10 +40,10 @@ func ReadProcess(pid int) (model.Process, error) {
 		WorkingDir:     info.Cwd,
 		GitRepo:        gitRepo,
 		GitBranch:      gitBranch,
+		Git:            gitInfo,
 		ListeningPorts: ports,
 		BindAddresses:  addrs,
 		Health:         "healthy",
-		Forked:         "unknown",
 		Env:            info.Env,
 		Service:        serviceName,
 		Container:      container,
@@ -120,41 +121,3 @@ func detectContainer(cmdline string) string {
 
 	return ""
 }
-
-func detectGitInfo(cwd string) (string, string) {
-	if cwd == "" {
-		return "", ""
-	}
-
-	searchDir := cwd
-	// Walk up 5 levels max to avoid endless loops or too much IO
-	for i := 0; i < 5; i++ {
-		gitDir := filepath.Join(searchDir, ".git")
-		if fi, err := os.Stat(gitDir); err == nil && fi.IsDir() {
-			gitRepo := filepath.Base(searchDir)
-
-			gitBranch := ""
-			headFile := filepath.Join(gitDir, "HEAD")
-			if head, err := os.ReadFile(headFile); err == nil {
-				headStr := strings.TrimSpace(string(head))
-				if strings.HasPrefix(headStr, "ref: ") {
-					ref := strings.TrimPrefix(headStr, "ref: ")
-					refParts := strings.Split(ref, "/")
-					if len(refParts) > 0 {
-						gitBranch = refParts[len(refParts)-1]
-					}
-				}
-			}
-
-			return gitRepo, gitBranch
-		}
-
-		parent := filepath.Dir(searchDir)
-		if parent == searchDir {
-			break
-		}
-		searchDir = parent
-	}
-
-	return "", ""
-}
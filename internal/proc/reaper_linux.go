@@ -0,0 +1,177 @@
+//go:build linux
+
+package proc
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"syscall"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+// knownSubreapers maps process names to the managers that mark themselves with
+// PR_SET_CHILD_SUBREAPER, so orphaned descendants are adopted by them instead of PID 1.
+// systemd only qualifies when it is not PID 1, i.e. a `systemd --user` instance.
+var knownSubreapers = map[string]string{
+	"systemd":         "systemd --user",
+	"tini":            "tini",
+	"catatonit":       "catatonit",
+	"conmon":          "conmon",
+	"containerd-shim": "containerd-shim",
+}
+
+// ResolveZombie identifies the parent that is failing to reap a zombie target
+// and how many other zombie children it is holding.
+func ResolveZombie(ancestry []model.Process) *model.ZombieInfo {
+	return resolveZombie("/proc", ancestry)
+}
+
+func resolveZombie(procRoot string, ancestry []model.Process) *model.ZombieInfo {
+	if len(ancestry) < 2 {
+		return nil
+	}
+	target := ancestry[len(ancestry)-1]
+	if target.Health != "zombie" {
+		return nil
+	}
+	parent := ancestry[len(ancestry)-2]
+
+	info := &model.ZombieInfo{
+		ParentPID:       parent.PID,
+		ParentCommand:   parent.Command,
+		ZombieCount:     1,
+		OldestStartedAt: target.StartedAt,
+	}
+
+	processes, err := readProcessSnapshot(procRoot)
+	if err != nil {
+		return info
+	}
+
+	info.ZombieCount = 0
+	for _, p := range processes {
+		if p.PPID != parent.PID || p.Health != "zombie" {
+			continue
+		}
+		info.ZombieCount++
+		if !p.StartedAt.IsZero() && (info.OldestStartedAt.IsZero() || p.StartedAt.Before(info.OldestStartedAt)) {
+			info.OldestStartedAt = p.StartedAt
+		}
+	}
+	// The target may have been reaped between reads; it still counts as observed
+	if info.ZombieCount == 0 {
+		info.ZombieCount = 1
+	}
+
+	return info
+}
+
+// ResolveOrphan reports whether the target was reparented to PID 1 or a
+// subreaper after the process that started it went away. Processes spawned
+// directly by a service manager are not orphans: they lead their own session
+// or share it with an ancestor that is still in the chain.
+func ResolveOrphan(ancestry []model.Process) *model.OrphanInfo {
+	return resolveOrphan(ancestry, probeLeader)
+}
+
+// resolveOrphan takes a probe reporting whether a PID is alive and its command
+func resolveOrphan(ancestry []model.Process, probe func(pid int) (bool, string)) *model.OrphanInfo {
+	if len(ancestry) < 2 {
+		return nil
+	}
+	target := ancestry[len(ancestry)-1]
+	parent := ancestry[len(ancestry)-2]
+
+	reaperLabel, subreaper := subreaperLabel(parent)
+	if parent.PID != 1 && !subreaper {
+		return nil
+	}
+
+	inChain := make(map[int]bool, len(ancestry))
+	for _, p := range ancestry {
+		inChain[p.PID] = true
+	}
+
+	// Prefer the process group leader (the job that started us), then the session leader
+	leader := 0
+	for _, id := range []int{target.Session.PGID, target.Session.SID} {
+		if id > 0 && !inChain[id] {
+			leader = id
+			break
+		}
+	}
+	if leader == 0 {
+		return nil
+	}
+
+	if reaperLabel == "" {
+		reaperLabel = parent.Command
+	}
+
+	info := &model.OrphanInfo{
+		ReaperPID:     parent.PID,
+		ReaperCommand: reaperLabel,
+		Subreaper:     subreaper,
+		LeaderPID:     leader,
+	}
+	info.LeaderAlive, info.LeaderCommand = probe(leader)
+
+	adopter := fmt.Sprintf("%s (pid %d)", reaperLabel, parent.PID)
+	if info.LeaderAlive && info.LeaderCommand != "" {
+		info.Explanation = fmt.Sprintf("Started under %s (pid %d), but its original parent exited and it was reparented to %s", info.LeaderCommand, leader, adopter)
+	} else {
+		info.Explanation = fmt.Sprintf("Its original parent and session/group leader (pid %d) exited; it was reparented to %s", leader, adopter)
+	}
+	if subreaper {
+		info.Explanation += ", a PR_SET_CHILD_SUBREAPER manager that adopts orphaned descendants instead of PID 1"
+	}
+
+	return info
+}
+
+// subreaperLabel returns a display label and true if p is a known subreaper.
+func subreaperLabel(p model.Process) (string, bool) {
+	if p.PID == 1 {
+		return "", false
+	}
+	label, ok := knownSubreapers[p.Command]
+	return label, ok
+}
+
+// readStatFields returns the fields of /proc/<pid>/stat following the command
+// name, so fields[0] is the state and fields[1] the ppid.
+func readStatFields(pid int) ([]string, error) {
+	stat, err := os.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+	if err != nil {
+		return nil, err
+	}
+	raw := string(stat)
+	close := strings.LastIndex(raw, ")")
+	if close == -1 || close+2 > len(raw) {
+		return nil, fmt.Errorf("invalid stat format")
+	}
+	return strings.Fields(raw[close+2:]), nil
+}
+
+func readComm(pid int) string {
+	data, err := os.ReadFile(fmt.Sprintf("/proc/%d/comm", pid))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+func probeLeader(pid int) (bool, string) {
+	if !pidAlive(pid) {
+		return false, ""
+	}
+	return true, readComm(pid)
+}
+
+// pidAlive probes a PID with signal 0; EPERM still means the process exists.
+func pidAlive(pid int) bool {
+	err := syscall.Kill(pid, 0)
+	return err == nil || err == syscall.EPERM
+}
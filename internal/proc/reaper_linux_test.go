@@ -0,0 +1,126 @@
+//go:build linux
+
+package proc
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+// writeStat adds /proc/<pid>/stat to a fixture procfs with the given state,
+// parent and start time in clock ticks
+func writeStat(t *testing.T, root string, pid int, comm, state string, ppid int, start int) {
+	t.Helper()
+	dir := filepath.Join(root, fmt.Sprint(pid))
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	// Fields after the command: state, ppid, then 17 zeros up to starttime
+	stat := fmt.Sprintf("%d (%s) %s %d %s%d 0 0\n", pid, comm, state, ppid, strings.Repeat("0 ", 17), start)
+	if err := os.WriteFile(filepath.Join(dir, "stat"), []byte(stat), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestResolveZombie(t *testing.T) {
+	root := t.TempDir()
+	writeStat(t, root, 1, "systemd", "S", 0, 1)
+	writeStat(t, root, 500, "app-server", "S", 1, 1000)
+	writeStat(t, root, 501, "worker", "Z", 500, 3000)
+	writeStat(t, root, 502, "worker", "Z", 500, 2000)
+	writeStat(t, root, 503, "worker", "S", 500, 1500)
+	writeStat(t, root, 600, "other", "Z", 1, 500)
+
+	snapshot, err := readProcessSnapshot(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var oldest model.Process
+	for _, p := range snapshot {
+		if p.PID == 502 {
+			oldest = p
+		}
+	}
+
+	ancestry := []model.Process{
+		{PID: 1, Command: "systemd"},
+		{PID: 500, PPID: 1, Command: "app-server"},
+		{PID: 501, PPID: 500, Command: "worker", Health: "zombie"},
+	}
+	info := resolveZombie(root, ancestry)
+	if info == nil {
+		t.Fatal("resolveZombie() = nil")
+	}
+	if info.ParentPID != 500 || info.ParentCommand != "app-server" || info.ZombieCount != 2 {
+		t.Errorf("unexpected info: %+v", info)
+	}
+	if !info.OldestStartedAt.Equal(oldest.StartedAt) {
+		t.Errorf("OldestStartedAt = %v, want %v", info.OldestStartedAt, oldest.StartedAt)
+	}
+
+	// Reaped between reads: the target still counts
+	ancestry[2].PID = 700
+	if info := resolveZombie(t.TempDir(), ancestry); info == nil || info.ZombieCount != 1 {
+		t.Errorf("empty procfs: %+v, want one zombie", info)
+	}
+
+	ancestry[2].Health = "healthy"
+	if info := resolveZombie(root, ancestry); info != nil {
+		t.Errorf("live target: %+v, want nil", info)
+	}
+}
+
+func TestResolveOrphan(t *testing.T) {
+	probe := func(pid int) (bool, string) {
+		if pid == 300 {
+			return true, "bash"
+		}
+		return false, ""
+	}
+	target := func(pgid, sid int) model.Process {
+		return model.Process{PID: 400, Command: "node", Session: model.SessionInfo{PGID: pgid, SID: sid}}
+	}
+	initProc := model.Process{PID: 1, Command: "systemd"}
+	userManager := model.Process{PID: 90, PPID: 1, Command: "systemd"}
+
+	tests := []struct {
+		name      string
+		ancestry  []model.Process
+		leader    int
+		alive     bool
+		subreaper bool
+		reaper    string
+		explain   string
+	}{
+		{"reparented to init", []model.Process{initProc, target(400, 300)}, 300, true, false, "systemd", "Started under bash (pid 300)"},
+		{"leader gone", []model.Process{initProc, target(250, 250)}, 250, false, false, "systemd", "session/group leader (pid 250) exited"},
+		{"user manager subreaper", []model.Process{initProc, userManager, target(250, 250)}, 250, false, true, "systemd --user", "PR_SET_CHILD_SUBREAPER"},
+		{"own session", []model.Process{initProc, target(400, 400)}, 0, false, false, "", ""},
+		{"ordinary parent", []model.Process{initProc, {PID: 300, PPID: 1, Command: "bash"}, target(250, 250)}, 0, false, false, "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := resolveOrphan(tt.ancestry, probe)
+			if tt.leader == 0 {
+				if info != nil {
+					t.Fatalf("resolveOrphan() = %+v, want nil", info)
+				}
+				return
+			}
+			if info == nil {
+				t.Fatal("resolveOrphan() = nil")
+			}
+			if info.LeaderPID != tt.leader || info.LeaderAlive != tt.alive || info.Subreaper != tt.subreaper || info.ReaperCommand != tt.reaper {
+				t.Errorf("unexpected info: %+v", info)
+			}
+			if !strings.Contains(info.Explanation, tt.explain) {
+				t.Errorf("Explanation = %q, want it to mention %q", info.Explanation, tt.explain)
+			}
+		})
+	}
+}
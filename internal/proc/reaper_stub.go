@@ -0,0 +1,13 @@
+//go:build !linux
+
+package proc
+
+import "github.com/pranshuparmar/witr/pkg/model"
+
+func ResolveZombie(ancestry []model.Process) *model.ZombieInfo {
+	return nil
+}
+
+func ResolveOrphan(ancestry []model.Process) *model.OrphanInfo {
+	return nil
+}
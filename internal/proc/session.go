@@ -0,0 +1,48 @@
+package proc
+
+import "github.com/pranshuparmar/witr/pkg/model"
+
+// ttyProbe reports whether a process has a controlling terminal and whether it is alive.
+type ttyProbe func(pid int) (hasTTY, alive bool)
+
+// classifySession derives the job control mode of a process from its session,
+// process group and terminal:
+//
+//   - foreground: attached to a terminal and owning its foreground process group
+//   - background: attached to a terminal but not in the foreground group
+//   - detached:   escaped its login session via setsid, nohup or disown
+//   - daemon:     session leader (or member of one) with no controlling terminal
+func classifySession(pid, ppid int, s model.SessionInfo, probe ttyProbe) string {
+	if s.SID <= 0 {
+		return ""
+	}
+
+	if s.TTY != "" {
+		if s.TPGID == s.PGID {
+			return "foreground"
+		}
+		// The shell that owned the terminal is gone but the job kept running
+		if s.SID != pid {
+			if _, alive := probe(s.SID); !alive {
+				return "detached"
+			}
+		}
+		return "background"
+	}
+
+	if s.SID == pid {
+		// A new session started straight from an interactive shell is a setsid escape
+		if parentTTY, _ := probe(ppid); parentTTY {
+			return "detached"
+		}
+		return "daemon"
+	}
+
+	// Without a terminal of our own, judge by the session we belong to: a
+	// daemon's session never had one, a dead login session means we outlived it
+	leaderTTY, alive := probe(s.SID)
+	if !alive || leaderTTY {
+		return "detached"
+	}
+	return "daemon"
+}
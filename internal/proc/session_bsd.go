@@ -0,0 +1,67 @@
+//go:build darwin || freebsd
+
+package proc
+
+import (
+	"os/exec"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+// readSession gathers the process group and terminal from ps and the
+// session ID from getsid(2).
+func readSession(pid, ppid int) model.SessionInfo {
+	cmd := exec.Command("ps", "-p", strconv.Itoa(pid), "-o", "pgid=", "-o", "tpgid=", "-o", "tty=")
+	cmd.Env = buildEnvForPS()
+	out, err := cmd.Output()
+	if err != nil {
+		return model.SessionInfo{}
+	}
+	fields := strings.Fields(string(out))
+	if len(fields) < 3 {
+		return model.SessionInfo{}
+	}
+
+	pgid, _ := strconv.Atoi(fields[0])
+	tpgid, _ := strconv.Atoi(fields[1])
+	sid, err := syscall.Getsid(pid)
+	if err != nil {
+		return model.SessionInfo{}
+	}
+
+	s := model.SessionInfo{
+		SID:  sid,
+		PGID: pgid,
+		TTY:  normalizeBSDTTY(fields[2]),
+	}
+	if tpgid > 0 {
+		s.TPGID = tpgid
+	}
+	s.Mode = classifySession(pid, ppid, s, probeTTY)
+	return s
+}
+
+// normalizeBSDTTY maps ps placeholders for "no terminal" to an empty string.
+func normalizeBSDTTY(tty string) string {
+	switch tty {
+	case "", "?", "??", "-":
+		return ""
+	}
+	return tty
+}
+
+func probeTTY(pid int) (bool, bool) {
+	if pid <= 0 {
+		return false, false
+	}
+	cmd := exec.Command("ps", "-p", strconv.Itoa(pid), "-o", "tty=")
+	cmd.Env = buildEnvForPS()
+	out, err := cmd.Output()
+	if err != nil {
+		return false, false
+	}
+	return normalizeBSDTTY(strings.TrimSpace(string(out))) != "", true
+}
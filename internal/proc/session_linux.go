@@ -0,0 +1,80 @@
+//go:build linux
+
+package proc
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+// readSession decodes pgrp, session, tty_nr and tpgid from the fields of
+// /proc/<pid>/stat that follow the command name.
+func readSession(pid, ppid int, fields []string) model.SessionInfo {
+	if len(fields) < 6 {
+		return model.SessionInfo{}
+	}
+
+	pgid, _ := strconv.Atoi(fields[2])
+	sid, _ := strconv.Atoi(fields[3])
+	ttyNr, _ := strconv.Atoi(fields[4])
+	tpgid, _ := strconv.Atoi(fields[5])
+
+	s := model.SessionInfo{
+		SID:  sid,
+		PGID: pgid,
+		TTY:  ttyName(ttyNr),
+	}
+	// tpgid is -1 when there is no controlling terminal
+	if tpgid > 0 {
+		s.TPGID = tpgid
+	}
+	s.Mode = classifySession(pid, ppid, s, probeTTY)
+	return s
+}
+
+// ttyName maps a tty_nr device number to its name under /dev.
+func ttyName(ttyNr int) string {
+	if ttyNr == 0 {
+		return ""
+	}
+	major := (ttyNr >> 8) & 0xfff
+	minor := (ttyNr & 0xff) | ((ttyNr >> 12) & 0xfff00)
+
+	// Unix98 pseudo-terminals occupy majors 136-143
+	if major >= 136 && major <= 143 {
+		return fmt.Sprintf("pts/%d", (major-136)*256+minor)
+	}
+
+	if uevent, err := os.ReadFile(fmt.Sprintf("/sys/dev/char/%d:%d/uevent", major, minor)); err == nil {
+		for line := range strings.Lines(string(uevent)) {
+			if name, ok := strings.CutPrefix(strings.TrimSpace(line), "DEVNAME="); ok {
+				return name
+			}
+		}
+	}
+
+	switch {
+	case major == 4 && minor < 64:
+		return fmt.Sprintf("tty%d", minor)
+	case major == 4:
+		return fmt.Sprintf("ttyS%d", minor-64)
+	case major == 5 && minor == 1:
+		return "console"
+	}
+	return fmt.Sprintf("%d:%d", major, minor)
+}
+
+func probeTTY(pid int) (bool, bool) {
+	if pid <= 0 {
+		return false, false
+	}
+	fields, err := readStatFields(pid)
+	if err != nil {
+		return false, false
+	}
+	return len(fields) > 4 && fields[4] != "0", true
+}
@@ -0,0 +1,44 @@
+package proc
+
+import (
+	"testing"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+func TestClassifySession(t *testing.T) {
+	// pid 10 is an interactive shell on a terminal, pid 20 a daemon with none
+	probe := func(pid int) (bool, bool) {
+		switch pid {
+		case 10:
+			return true, true
+		case 20:
+			return false, true
+		}
+		return false, false
+	}
+
+	tests := []struct {
+		name    string
+		pid     int
+		ppid    int
+		session model.SessionInfo
+		want    string
+	}{
+		{"foreground job", 100, 10, model.SessionInfo{SID: 10, PGID: 100, TPGID: 100, TTY: "pts/3"}, "foreground"},
+		{"background job", 100, 10, model.SessionInfo{SID: 10, PGID: 100, TPGID: 10, TTY: "pts/3"}, "background"},
+		{"terminal session ended", 100, 1, model.SessionInfo{SID: 30, PGID: 100, TPGID: 30, TTY: "pts/3"}, "detached"},
+		{"setsid from shell", 100, 10, model.SessionInfo{SID: 100, PGID: 100}, "detached"},
+		{"daemon session leader", 100, 1, model.SessionInfo{SID: 100, PGID: 100}, "daemon"},
+		{"daemon worker", 100, 20, model.SessionInfo{SID: 20, PGID: 20}, "daemon"},
+		{"disowned after logout", 100, 1, model.SessionInfo{SID: 30, PGID: 100}, "detached"},
+		{"no session", 2, 0, model.SessionInfo{}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := classifySession(tt.pid, tt.ppid, tt.session, probe); got != tt.want {
+				t.Fatalf("classifySession() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+//go:build linux
+
+package proc
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+// linuxSignalNames maps standard signal numbers to names (x86/arm layout)
+var linuxSignalNames = map[int]string{
+	1: "SIGHUP", 2: "SIGINT", 3: "SIGQUIT", 4: "SIGILL", 5: "SIGTRAP",
+	6: "SIGABRT", 7: "SIGBUS", 8: "SIGFPE", 9: "SIGKILL", 10: "SIGUSR1",
+	11: "SIGSEGV", 12: "SIGUSR2", 13: "SIGPIPE", 14: "SIGALRM", 15: "SIGTERM",
+	16: "SIGSTKFLT", 17: "SIGCHLD", 18: "SIGCONT", 19: "SIGSTOP", 20: "SIGTSTP",
+	21: "SIGTTIN", 22: "SIGTTOU", 23: "SIGURG", 24: "SIGXCPU", 25: "SIGXFSZ",
+	26: "SIGVTALRM", 27: "SIGPROF", 28: "SIGWINCH", 29: "SIGIO", 30: "SIGPWR",
+	31: "SIGSYS",
+}
+
+// ReadSignals decodes SigIgn, SigCgt, SigBlk and the pending sets from
+// /proc/<pid>/status.
+func ReadSignals(pid int) *model.SignalInfo {
+	data, err := os.ReadFile(fmt.Sprintf("/proc/%d/status", pid))
+	if err != nil {
+		return nil
+	}
+	return parseSignalStatus(string(data))
+}
+
+// parseSignalStatus reads the signal masks of a status file. Pending merges
+// SigPnd, signals queued for the thread itself, with ShdPnd, where signals
+// sent to the whole process with kill() wait.
+func parseSignalStatus(status string) *model.SignalInfo {
+	info := &model.SignalInfo{}
+	var pending uint64
+	for line := range strings.Lines(status) {
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch key {
+		case "SigIgn":
+			info.Ignored = decodeSignalMask(value)
+		case "SigCgt":
+			info.Caught = decodeSignalMask(value)
+		case "SigBlk":
+			info.Blocked = decodeSignalMask(value)
+		case "SigPnd", "ShdPnd":
+			if mask, err := strconv.ParseUint(value, 16, 64); err == nil {
+				pending |= mask
+			}
+		}
+	}
+	info.Pending = maskSignals(pending)
+	return info
+}
+
+// decodeSignalMask turns a hex mask where bit n-1 represents signal n into signal names.
+func decodeSignalMask(hexMask string) []string {
+	mask, err := strconv.ParseUint(hexMask, 16, 64)
+	if err != nil {
+		return nil
+	}
+	return maskSignals(mask)
+}
+
+func maskSignals(mask uint64) []string {
+	var names []string
+	for bit := 0; bit < 64; bit++ {
+		if mask&(1<<bit) != 0 {
+			names = append(names, signalName(bit+1))
+		}
+	}
+	return names
+}
+
+// signalName returns the name of a signal number, numbering real-time
+// signals the way `kill -l` does (glibc reserves 32 and 33).
+func signalName(sig int) string {
+	if name, ok := linuxSignalNames[sig]; ok {
+		return name
+	}
+	const rtMin, rtMax = 34, 64
+	switch {
+	case sig == rtMin:
+		return "SIGRTMIN"
+	case sig == rtMax:
+		return "SIGRTMAX"
+	case sig > rtMin && sig <= (rtMin+rtMax)/2:
+		return fmt.Sprintf("SIGRTMIN+%d", sig-rtMin)
+	case sig > rtMin && sig < rtMax:
+		return fmt.Sprintf("SIGRTMAX-%d", rtMax-sig)
+	}
+	return fmt.Sprintf("SIG%d", sig)
+}
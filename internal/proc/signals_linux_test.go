@@ -0,0 +1,44 @@
+//go:build linux
+
+package proc
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestDecodeSignalMask(t *testing.T) {
+	tests := []struct {
+		name string
+		mask string
+		want []string
+	}{
+		{"none", "0000000000000000", nil},
+		{"nohup", "0000000000000001", []string{"SIGHUP"}},
+		{"background job", "0000000000000006", []string{"SIGINT", "SIGQUIT"}},
+		{"term and chld", "0000000000014000", []string{"SIGTERM", "SIGCHLD"}},
+		{"realtime", "0000000200000000", []string{"SIGRTMIN"}},
+		{"rtmax", "8000000000000000", []string{"SIGRTMAX"}},
+		{"invalid", "zz", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := decodeSignalMask(tt.mask); !slices.Equal(got, tt.want) {
+				t.Fatalf("decodeSignalMask(%q) = %v, want %v", tt.mask, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSignalStatusPending(t *testing.T) {
+	status := "Name:\tworker\nSigQ:\t1/63319\nSigPnd:\t0000000000000000\nShdPnd:\t0000000000004000\n" +
+		"SigBlk:\t0000000000004000\nSigIgn:\t0000000000000001\nSigCgt:\t0000000000010000\n"
+
+	info := parseSignalStatus(status)
+	if !slices.Equal(info.Pending, []string{"SIGTERM"}) {
+		t.Errorf("Pending = %v, want SIGTERM from ShdPnd", info.Pending)
+	}
+	if !slices.Equal(info.Blocked, []string{"SIGTERM"}) || !slices.Equal(info.Ignored, []string{"SIGHUP"}) || !slices.Equal(info.Caught, []string{"SIGCHLD"}) {
+		t.Errorf("unexpected masks: %+v", info)
+	}
+}
@@ -0,0 +1,9 @@
+//go:build !linux
+
+package proc
+
+import "github.com/pranshuparmar/witr/pkg/model"
+
+func ReadSignals(pid int) *model.SignalInfo {
+	return nil
+}
@@ -8,9 +8,19 @@ import (
 	"os/exec"
 	"strconv"
 	"strings"
+
+	"github.com/pranshuparmar/witr/internal/systemd"
 )
 
 func GetSystemdRestartCount(unitName string) (int, error) {
+	if c, err := systemd.System(); err == nil {
+		if v, err := c.Property(unitName, "NRestarts"); err == nil {
+			if n, ok := v.(uint32); ok {
+				return int(n), nil
+			}
+		}
+	}
+
 	if _, err := exec.LookPath("systemctl"); err != nil {
 		return 0, fmt.Errorf("systemctl not found")
 	}
@@ -36,44 +46,74 @@ func GetSystemdRestartCount(unitName string) (int, error) {
 }
 
 // ResolveSystemdService attempts to find the systemd service name associated with a port.
-// It uses `systemctl list-sockets` to find the socket unit and then maps it to the service unit.
+// It lists socket units over D-Bus, or with `systemctl list-sockets` when the bus
+// is unreachable or the listing fails, and maps the socket unit to the service
+// unit it triggers.
 func ResolveSystemdService(port int) (string, error) {
-	// check if systemctl is available
-	if _, err := exec.LookPath("systemctl"); err != nil {
-		return "", fmt.Errorf("systemctl not found")
+	sockets, err := busSockets()
+	if err != nil {
+		// check if systemctl is available
+		if _, err := exec.LookPath("systemctl"); err != nil {
+			return "", fmt.Errorf("systemctl not found")
+		}
+		out, err := exec.Command("systemctl", "list-sockets", "--no-legend", "--full").Output()
+		if err != nil {
+			return "", err
+		}
+		sockets = systemd.ParseListSockets(string(out))
 	}
 
-	cmd := exec.Command("systemctl", "list-sockets", "--no-legend", "--full")
-	var out bytes.Buffer
-	cmd.Stdout = &out
-	if err := cmd.Run(); err != nil {
-		return "", err
+	for _, s := range sockets {
+		if s.ListensOnPort(port) {
+			return s.Activates(), nil
+		}
 	}
+	return "", fmt.Errorf("no systemd service found for port %d", port)
+}
 
-	portStr := fmt.Sprintf(":%d", port)
-	lines := strings.Split(out.String(), "\n")
-
-	var socketUnit string
-
-	for _, line := range lines {
-		fields := strings.Fields(line)
-		if len(fields) < 3 {
-			continue
-		}
-		listen := fields[0]
+// busSockets lists socket units over the system bus
+func busSockets() ([]systemd.Socket, error) {
+	c, err := systemd.System()
+	if err != nil {
+		return nil, err
+	}
+	return c.Sockets()
+}
 
-		if strings.HasSuffix(listen, portStr) {
-			socketUnit = fields[1]
-			service := fields[2]
-			return service, nil
+// resolveServiceForPID returns the .service unit managing pid, asking systemd
+// over D-Bus and falling back to parsing `systemctl status <pid>` when the bus
+// is unreachable or the call fails.
+func resolveServiceForPID(pid int) string {
+	if c, err := systemd.System(); err == nil {
+		if unit, err := c.UnitByPID(pid); err == nil {
+			if !strings.HasSuffix(unit, ".service") {
+				return ""
+			}
+			// Processes of a systemd --user instance belong to its own units
+			if uid, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(unit, "user@"), ".service")); err == nil && strings.HasPrefix(unit, "user@") {
+				if uc, err := systemd.User(uid); err == nil {
+					if u, err := uc.UnitByPID(pid); err == nil && strings.HasSuffix(u, ".service") {
+						return u
+					}
+				}
+			}
+			return unit
 		}
 	}
 
-	if socketUnit != "" {
-		if strings.HasSuffix(socketUnit, ".socket") {
-			return strings.TrimSuffix(socketUnit, ".socket") + ".service", nil
+	svcOut, err := exec.Command("systemctl", "status", strconv.Itoa(pid)).CombinedOutput()
+	if err != nil || !strings.Contains(string(svcOut), "Loaded: loaded") {
+		return ""
+	}
+	// Try to extract service name from output
+	for line := range strings.Lines(string(svcOut)) {
+		if strings.HasPrefix(line, "Loaded:") && strings.Contains(line, ".service") {
+			for _, part := range strings.Fields(line) {
+				if strings.HasSuffix(part, ".service") {
+					return part
+				}
+			}
 		}
 	}
-
-	return "", fmt.Errorf("no systemd service found for port %d", port)
+	return ""
 }
@@ -0,0 +1,137 @@
+package proc
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+// ResolveTree builds the descendant tree of pid from one process snapshot.
+// depth limits how many levels below pid are expanded; depth <= 0 means unlimited.
+func ResolveTree(pid int, depth int) (*model.ProcessTree, error) {
+	if pid <= 0 {
+		return nil, fmt.Errorf("invalid pid")
+	}
+
+	processes, err := ListProcesses()
+	if err != nil {
+		return nil, err
+	}
+
+	tree := buildTree(processes, pid, depth)
+	if tree == nil {
+		return nil, fmt.Errorf("process %d not found in snapshot", pid)
+	}
+	return tree, nil
+}
+
+func buildTree(processes []model.Process, pid int, depth int) *model.ProcessTree {
+	byPID := make(map[int]model.Process, len(processes))
+	children := make(map[int][]model.Process)
+	for _, p := range processes {
+		byPID[p.PID] = p
+		// PID 0 on some platforms lists itself as its own parent
+		if p.PPID != p.PID {
+			children[p.PPID] = append(children[p.PPID], p)
+		}
+	}
+	for _, list := range children {
+		sortProcesses(list)
+	}
+
+	root, ok := byPID[pid]
+	if !ok {
+		return nil
+	}
+
+	seen := make(map[int]bool)
+	var build func(p model.Process, level int) *model.ProcessTree
+	build = func(p model.Process, level int) *model.ProcessTree {
+		seen[p.PID] = true
+		node := &model.ProcessTree{
+			PID:             p.PID,
+			Command:         p.Command,
+			Cmdline:         p.Cmdline,
+			CPUPercent:      p.CPUPercent,
+			MemoryRSS:       p.MemoryRSS,
+			TotalCPUPercent: p.CPUPercent,
+			TotalMemoryRSS:  p.MemoryRSS,
+		}
+		for _, c := range children[p.PID] {
+			if seen[c.PID] {
+				continue // loop protection
+			}
+			child := build(c, level+1)
+			node.TotalCPUPercent += child.TotalCPUPercent
+			node.TotalMemoryRSS += child.TotalMemoryRSS
+			if depth > 0 && level >= depth {
+				node.Hidden += 1 + child.Hidden + countNodes(child.Children)
+				continue
+			}
+			node.Children = append(node.Children, child)
+		}
+		node.Children = collapseSiblings(node.Children)
+		return node
+	}
+
+	return build(root, 0)
+}
+
+// countNodes counts the nodes in a list of subtrees, expanding collapsed groups
+func countNodes(nodes []*model.ProcessTree) int {
+	n := 0
+	for _, c := range nodes {
+		if c.Count > 0 {
+			n += c.Count
+		} else {
+			n++
+		}
+		n += c.Hidden + countNodes(c.Children)
+	}
+	return n
+}
+
+// collapseSiblings merges leaf siblings that run the same command line, such as
+// a pool of identical workers, into a single node with a count.
+func collapseSiblings(nodes []*model.ProcessTree) []*model.ProcessTree {
+	groups := make(map[string][]*model.ProcessTree)
+	for _, n := range nodes {
+		if len(n.Children) == 0 && n.Hidden == 0 {
+			key := n.Command + "\x00" + n.Cmdline
+			groups[key] = append(groups[key], n)
+		}
+	}
+
+	out := make([]*model.ProcessTree, 0, len(nodes))
+	emitted := make(map[string]bool)
+	for _, n := range nodes {
+		key := n.Command + "\x00" + n.Cmdline
+		group := groups[key]
+		if len(n.Children) > 0 || n.Hidden > 0 || len(group) < 2 {
+			out = append(out, n)
+			continue
+		}
+		if emitted[key] {
+			continue
+		}
+		emitted[key] = true
+
+		merged := &model.ProcessTree{
+			PID:     group[0].PID,
+			Command: n.Command,
+			Cmdline: n.Cmdline,
+			Count:   len(group),
+		}
+		for _, g := range group {
+			merged.PIDs = append(merged.PIDs, g.PID)
+			merged.CPUPercent += g.CPUPercent
+			merged.MemoryRSS += g.MemoryRSS
+		}
+		sort.Ints(merged.PIDs)
+		merged.TotalCPUPercent = merged.CPUPercent
+		merged.TotalMemoryRSS = merged.MemoryRSS
+		out = append(out, merged)
+	}
+	return out
+}
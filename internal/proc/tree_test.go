@@ -0,0 +1,71 @@
+package proc
+
+import (
+	"testing"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+func treeFixture() []model.Process {
+	procs := []model.Process{
+		{PID: 1, PPID: 0, Command: "systemd"},
+		{PID: 100, PPID: 1, Command: "php-fpm", Cmdline: "php-fpm: master process", CPUPercent: 1, MemoryRSS: 10 << 20},
+		{PID: 200, PPID: 100, Command: "sh", Cmdline: "sh -c backup", MemoryRSS: 1 << 20},
+		{PID: 201, PPID: 200, Command: "tar", Cmdline: "tar czf -", CPUPercent: 5, MemoryRSS: 2 << 20},
+	}
+	for pid := 101; pid <= 104; pid++ {
+		procs = append(procs, model.Process{PID: pid, PPID: 100, Command: "php-fpm", Cmdline: "php-fpm: pool www", CPUPercent: 0.5, MemoryRSS: 20 << 20})
+	}
+	return procs
+}
+
+func TestBuildTreeCollapsesIdenticalSiblings(t *testing.T) {
+	tree := buildTree(treeFixture(), 100, 0)
+	if tree == nil {
+		t.Fatal("expected tree")
+	}
+	if len(tree.Children) != 2 {
+		t.Fatalf("expected 2 children (pool group + sh), got %d", len(tree.Children))
+	}
+
+	pool := tree.Children[0]
+	if pool.Count != 4 || pool.Cmdline != "php-fpm: pool www" || len(pool.PIDs) != 4 {
+		t.Fatalf("unexpected collapsed node: %+v", pool)
+	}
+	if pool.MemoryRSS != 80<<20 {
+		t.Fatalf("expected collapsed RSS of 80 MB, got %d", pool.MemoryRSS)
+	}
+
+	sh := tree.Children[1]
+	if sh.PID != 200 || len(sh.Children) != 1 || sh.Children[0].PID != 201 {
+		t.Fatalf("unexpected sh subtree: %+v", sh)
+	}
+
+	if tree.TotalCPUPercent != 1+2+5 {
+		t.Fatalf("unexpected CPU roll-up: %v", tree.TotalCPUPercent)
+	}
+	if tree.TotalMemoryRSS != (10+80+1+2)<<20 {
+		t.Fatalf("unexpected RSS roll-up: %d", tree.TotalMemoryRSS)
+	}
+}
+
+func TestBuildTreeDepthLimit(t *testing.T) {
+	tree := buildTree(treeFixture(), 1, 1)
+	if len(tree.Children) != 1 || tree.Children[0].PID != 100 {
+		t.Fatalf("expected php-fpm master as only child, got %+v", tree.Children)
+	}
+	master := tree.Children[0]
+	if len(master.Children) != 0 || master.Hidden != 6 {
+		t.Fatalf("expected 6 hidden descendants, got %d (children %d)", master.Hidden, len(master.Children))
+	}
+	// Roll-ups still include hidden descendants
+	if master.TotalMemoryRSS != (10+80+1+2)<<20 {
+		t.Fatalf("unexpected RSS roll-up: %d", master.TotalMemoryRSS)
+	}
+}
+
+func TestBuildTreeMissingRoot(t *testing.T) {
+	if tree := buildTree(treeFixture(), 999, 0); tree != nil {
+		t.Fatalf("expected nil, got %+v", tree)
+	}
+}
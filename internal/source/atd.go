@@ -0,0 +1,229 @@
+package source
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+// atSpoolDirs hold queued at/batch jobs: Debian's and the one used by Red Hat,
+// SUSE and the BSDs
+var atSpoolDirs = []string{"/var/spool/cron/atjobs", "/var/spool/at", "/var/at/jobs"}
+
+// atJob is a job file in the at spool
+type atJob struct {
+	File      string
+	ID        int
+	Queue     byte // a for at, b for batch, = while running
+	RunAt     time.Time
+	QueuedAt  time.Time
+	UID       int
+	User      string
+	Directory string
+	Commands  []string
+}
+
+func detectAt(ancestry []model.Process) *model.Source {
+	for i := len(ancestry) - 2; i >= 0; i-- {
+		if ancestry[i].Command != "atd" {
+			continue
+		}
+		src := &model.Source{
+			Type: model.SourceAt,
+			Name: "atd",
+		}
+		job := ancestry[i+1:]
+		var stdin []string
+		for _, p := range job {
+			if f := jobStdin(p.PID); f != "" {
+				stdin = append(stdin, f)
+			}
+		}
+		explainAtJob("/", src, job, stdin)
+		return src
+	}
+	return nil
+}
+
+// explainAtJob finds the spool file of the job and records its ID, owner,
+// queue time and commands
+func explainAtJob(root string, src *model.Source, job []model.Process, stdin []string) {
+	j, ok := matchAtJob(loadAtJobs(root), job, stdin)
+	if !ok {
+		return
+	}
+
+	src.UnitFile = j.File
+	d := map[string]string{
+		"at_job": strconv.Itoa(j.ID),
+		"queue":  atQueueName(j.Queue),
+	}
+	if j.User != "" {
+		d["queued_by"] = j.User
+	} else if j.UID >= 0 {
+		d["queued_by"] = "uid " + strconv.Itoa(j.UID)
+	}
+	if !j.QueuedAt.IsZero() {
+		d["queued_at"] = j.QueuedAt.Format("2006-01-02 15:04")
+	}
+	if !j.RunAt.IsZero() {
+		d["run_at"] = j.RunAt.Format("2006-01-02 15:04")
+	}
+	if j.Directory != "" {
+		d["at_dir"] = j.Directory
+	}
+	if len(j.Commands) > 0 {
+		d["command"] = strings.Join(j.Commands, "\n")
+	}
+	src.Details = d
+}
+
+func loadAtJobs(root string) []atJob {
+	var jobs []atJob
+	for _, dir := range atSpoolDirs {
+		entries, err := os.ReadDir(filepath.Join(root, dir))
+		if err != nil {
+			continue
+		}
+		for _, e := range entries {
+			file := path.Join(dir, e.Name())
+			j, ok := parseAtJobName(e.Name())
+			if !ok || !e.Type().IsRegular() {
+				continue
+			}
+			j.File = file
+			if info, err := e.Info(); err == nil {
+				j.QueuedAt = info.ModTime()
+			}
+			if data, err := os.ReadFile(filepath.Join(root, file)); err == nil {
+				parseAtJobScript(string(data), &j)
+			}
+			jobs = append(jobs, j)
+		}
+	}
+	return jobs
+}
+
+// parseAtJobName decodes a spool file name: the queue letter, five hex digits
+// of job number and eight of run time in minutes since the epoch
+func parseAtJobName(name string) (atJob, bool) {
+	if len(name) != 14 {
+		return atJob{}, false
+	}
+	id, err := strconv.ParseInt(name[1:6], 16, 64)
+	if err != nil {
+		return atJob{}, false
+	}
+	minutes, err := strconv.ParseInt(name[6:], 16, 64)
+	if err != nil {
+		return atJob{}, false
+	}
+	return atJob{
+		ID:    int(id),
+		Queue: name[0],
+		RunAt: time.Unix(minutes*60, 0),
+		UID:   -1,
+	}, true
+}
+
+// parseAtJobScript reads the owner from the "# atrun uid=" header, the
+// directory the job runs in and the user's commands from the here-document
+// at writes them into
+func parseAtJobScript(data string, j *atJob) {
+	delimiter := ""
+	inBody := false
+	for line := range strings.Lines(data) {
+		line = strings.TrimRight(line, "\n")
+		switch {
+		case inBody:
+			if line == delimiter {
+				inBody = false
+				continue
+			}
+			if t := strings.TrimSpace(line); t != "" && !strings.HasPrefix(t, "#") {
+				j.Commands = append(j.Commands, t)
+			}
+		case strings.HasPrefix(line, "# atrun uid="):
+			for _, f := range strings.Fields(strings.TrimPrefix(line, "# atrun ")) {
+				if v, ok := strings.CutPrefix(f, "uid="); ok {
+					if uid, err := strconv.Atoi(v); err == nil {
+						j.UID = uid
+					}
+				}
+			}
+		case strings.HasPrefix(line, "# mail "):
+			if f := strings.Fields(line); len(f) >= 3 {
+				j.User = f[2]
+			}
+		case strings.HasPrefix(line, "cd ") && j.Directory == "":
+			dir, _, _ := strings.Cut(strings.TrimPrefix(line, "cd "), " ||")
+			j.Directory = strings.TrimSpace(dir)
+		case strings.Contains(line, "<< '"):
+			// ${SHELL:-/bin/sh} << 'marcinDELIMITER...'
+			_, rest, _ := strings.Cut(line, "<< '")
+			delimiter, _, _ = strings.Cut(rest, "'")
+			inBody = delimiter != ""
+		}
+	}
+}
+
+// matchAtJob picks the spool file of the running job: the one a job process
+// reads on stdin, the only job atd marked as running, or the one whose
+// commands match a process command line.
+func matchAtJob(jobs []atJob, job []model.Process, stdin []string) (atJob, bool) {
+	for _, f := range stdin {
+		for _, j := range jobs {
+			if f == j.File || path.Base(f) == path.Base(j.File) {
+				return j, true
+			}
+		}
+	}
+
+	var running []atJob
+	for _, j := range jobs {
+		if j.Queue == '=' {
+			running = append(running, j)
+		}
+	}
+	if len(running) == 1 {
+		return running[0], true
+	}
+	if len(running) > 1 {
+		jobs = running
+	}
+
+	for _, p := range job {
+		cmd := normalizeSpace(p.Cmdline)
+		if c, ok := shellCommand(p.Cmdline); ok {
+			cmd = normalizeSpace(c)
+		}
+		if cmd == "" {
+			continue
+		}
+		for _, j := range jobs {
+			for _, c := range j.Commands {
+				if normalizeSpace(c) == cmd {
+					return j, true
+				}
+			}
+		}
+	}
+	return atJob{}, false
+}
+
+func atQueueName(q byte) string {
+	switch {
+	case q == '=':
+		return "running"
+	case q == 'b':
+		return "b (batch)"
+	case q >= 'A' && q <= 'Z':
+		return string(q) + " (batch)"
+	}
+	return string(q)
+}
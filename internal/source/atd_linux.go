@@ -0,0 +1,16 @@
+package source
+
+import (
+	"fmt"
+	"os"
+)
+
+// jobStdin returns the file on a process's standard input; atd runs each job
+// as a shell reading the spool file
+func jobStdin(pid int) string {
+	path, err := os.Readlink(fmt.Sprintf("/proc/%d/fd/0", pid))
+	if err != nil {
+		return ""
+	}
+	return path
+}
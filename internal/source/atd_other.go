@@ -0,0 +1,7 @@
+//go:build !linux
+
+package source
+
+func jobStdin(pid int) string {
+	return ""
+}
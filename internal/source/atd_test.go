@@ -0,0 +1,104 @@
+package source
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+const atJobScript = `#!/bin/sh
+# atrun uid=1000 gid=1000
+# mail alice 0
+umask 22
+HOME=/home/alice; export HOME
+cd /home/alice/reports || {
+	 echo 'Execution directory inaccessible' >&2
+	 exit 1
+}
+${SHELL:-/bin/sh} << 'marcinDELIMITER4c1b2e3a'
+./build-report.sh --month 10
+mail -s done alice < out.txt
+marcinDELIMITER4c1b2e3a
+`
+
+func TestParseAtJobName(t *testing.T) {
+	j, ok := parseAtJobName("a0000b01cc3a64")
+	if !ok {
+		t.Fatal("name not parsed")
+	}
+	if j.ID != 11 || j.Queue != 'a' || !j.RunAt.Equal(time.Unix(0x01cc3a64*60, 0)) {
+		t.Errorf("unexpected job: %+v", j)
+	}
+	if _, ok := parseAtJobName(".SEQ"); ok {
+		t.Error(".SEQ parsed as a job")
+	}
+}
+
+func TestExplainAtJob(t *testing.T) {
+	root := t.TempDir()
+	writeFixture(t, root, "var/spool/cron/atjobs/=0000b01cc3a64", atJobScript)
+	writeFixture(t, root, "var/spool/cron/atjobs/b0000c01cc3b00", "#!/bin/sh\n# atrun uid=0 gid=0\n# mail root 0\n${SHELL:-/bin/sh} << 'marcinDELIMITER00'\n/usr/local/bin/reindex\nmarcinDELIMITER00\n")
+	writeFixture(t, root, "var/spool/cron/atjobs/.SEQ", "0000c\n")
+	queued := time.Date(2026, 10, 19, 9, 41, 0, 0, time.Local)
+	if err := os.Chtimes(filepath.Join(root, "var/spool/cron/atjobs/=0000b01cc3a64"), queued, queued); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		job   []model.Process
+		stdin []string
+		file  string
+		want  map[string]string
+	}{
+		{
+			name:  "stdin",
+			job:   []model.Process{{Command: "sh", Cmdline: "sh"}},
+			stdin: []string{"/var/spool/cron/atjobs/b0000c01cc3b00"},
+			file:  "/var/spool/cron/atjobs/b0000c01cc3b00",
+			want:  map[string]string{"at_job": "12", "queue": "b (batch)", "queued_by": "root", "command": "/usr/local/bin/reindex"},
+		},
+		{
+			name: "only running job",
+			job:  []model.Process{{Command: "sh", Cmdline: "sh"}},
+			file: "/var/spool/cron/atjobs/=0000b01cc3a64",
+			want: map[string]string{
+				"at_job":    "11",
+				"queue":     "running",
+				"queued_by": "alice",
+				"queued_at": "2026-10-19 09:41",
+				"at_dir":    "/home/alice/reports",
+				"command":   "./build-report.sh --month 10\nmail -s done alice < out.txt",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src := &model.Source{Type: model.SourceAt, Name: "atd"}
+			explainAtJob(root, src, tt.job, tt.stdin)
+			if src.UnitFile != tt.file {
+				t.Fatalf("UnitFile = %q, want %q", src.UnitFile, tt.file)
+			}
+			for k, v := range tt.want {
+				if src.Details[k] != v {
+					t.Errorf("%s = %q, want %q", k, src.Details[k], v)
+				}
+			}
+		})
+	}
+}
+
+func TestMatchAtJobByCommand(t *testing.T) {
+	jobs := []atJob{
+		{File: "a1", Commands: []string{"/usr/bin/backup"}},
+		{File: "a2", Commands: []string{"./build-report.sh --month 10"}},
+	}
+	job := []model.Process{{Command: "sh", Cmdline: "sh"}, {Command: "build-report.sh", Cmdline: "/bin/bash ./build-report.sh --month 10"}, {Command: "bash", Cmdline: "./build-report.sh  --month 10"}}
+	j, ok := matchAtJob(jobs, job, nil)
+	if !ok || j.File != "a2" {
+		t.Fatalf("matched %+v, %v", j, ok)
+	}
+}
@@ -16,7 +16,13 @@ func detectContainer(ancestry []model.Process) *model.Source {
 		}
 		content := string(data)
 
+		// Pod cgroups also name the runtime, so they are checked first
 		switch {
+		case strings.Contains(content, "kubepods"):
+			return &model.Source{
+				Type: model.SourceContainer,
+				Name: "kubernetes",
+			}
 		case strings.Contains(content, "docker"):
 			return &model.Source{
 				Type: model.SourceContainer,
@@ -27,11 +33,6 @@ func detectContainer(ancestry []model.Process) *model.Source {
 				Type: model.SourceContainer,
 				Name: "podman",
 			}
-		case strings.Contains(content, "kubepods"):
-			return &model.Source{
-				Type: model.SourceContainer,
-				Name: "kubernetes",
-			}
 		case strings.Contains(content, "colima"):
 			return &model.Source{
 				Type: model.SourceContainer,
@@ -1,15 +1,189 @@
 package source
 
-import "github.com/pranshuparmar/witr/pkg/model"
+import (
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+// cronDaemons are the process names of cron implementations: Vixie/Debian
+// cron, cronie and busybox crond, anacron, and fcron
+var cronDaemons = map[string]string{
+	"cron":    "cron",
+	"crond":   "cron",
+	"CROND":   "cron",
+	"anacron": "anacron",
+	"fcron":   "fcron",
+}
+
+// runPartsDirs are the directories whose scripts run-parts starts from
+// /etc/crontab or anacrontab
+var runPartsDirs = []string{"/etc/cron.hourly", "/etc/cron.daily", "/etc/cron.weekly", "/etc/cron.monthly"}
 
 func detectCron(ancestry []model.Process) *model.Source {
-	for _, p := range ancestry {
-		if p.Command == "cron" || p.Command == "crond" {
-			return &model.Source{
-				Type: model.SourceCron,
-				Name: "cron",
-			}
+	// The daemon itself is not a cron job; only what it started is
+	for i := len(ancestry) - 2; i >= 0; i-- {
+		name, ok := cronDaemons[ancestry[i].Command]
+		if !ok {
+			continue
+		}
+		src := &model.Source{
+			Type: model.SourceCron,
+			Name: name,
 		}
+		explainCronJob("/", src, ancestry[i+1:], time.Now())
+		return src
 	}
 	return nil
 }
+
+// explainCronJob finds the crontab entry behind the job processes and fills
+// in its file, line, schedule and next run
+func explainCronJob(root string, src *model.Source, job []model.Process, now time.Time) {
+	e, script, ok := matchCronEntry(loadCronEntries(root), job, src.Name == "anacron")
+	if !ok {
+		return
+	}
+
+	src.UnitFile = e.File
+	d := map[string]string{
+		"cron_line": strconv.Itoa(e.Line),
+		"schedule":  e.Schedule,
+		"command":   e.Command,
+	}
+	if e.User != "" {
+		d["cron_user"] = e.User
+	}
+	if e.Anacron {
+		d["schedule"] = anacronPeriod(e.Schedule) + " (anacron job " + e.JobID + ")"
+	}
+	if script != "" {
+		d["script"] = script
+	}
+	if next, ok := nextCronRun(root, e, now); ok {
+		layout := "2006-01-02 15:04"
+		if e.Anacron {
+			layout = "2006-01-02"
+		}
+		d["next_run"] = next.Format(layout)
+		if !e.Anacron {
+			d["next_run"] += " (in " + until(next.Sub(now)) + ")"
+		}
+	}
+	src.Details = d
+}
+
+// matchCronEntry picks the entry that started job, the processes below the
+// cron daemon. cron runs each command through `sh -c`; a simple command may
+// have been exec'd in place of the shell, and run-parts jobs are found through
+// the script directory they name.
+func matchCronEntry(entries []cronEntry, job []model.Process, anacron bool) (cronEntry, string, bool) {
+	var commands []string
+	for _, p := range job {
+		if c, ok := shellCommand(p.Cmdline); ok {
+			commands = append(commands, c)
+		} else if p.Cmdline != "" {
+			commands = append(commands, p.Cmdline)
+		}
+	}
+
+	candidates := make([]cronEntry, 0, len(entries))
+	for _, e := range entries {
+		if e.Anacron != anacron {
+			continue
+		}
+		if len(job) > 0 && job[0].User != "" && job[0].User != "unknown" && e.User != "" && e.User != job[0].User {
+			continue
+		}
+		candidates = append(candidates, e)
+	}
+
+	script := runPartsScript(job)
+	for _, c := range commands {
+		for _, e := range candidates {
+			if normalizeSpace(e.Command) == normalizeSpace(c) {
+				return e, script, true
+			}
+		}
+	}
+
+	if script != "" {
+		dir := path.Dir(script)
+		for _, e := range candidates {
+			if commandMentions(e.Command, dir) {
+				return e, script, true
+			}
+		}
+	}
+	return cronEntry{}, "", false
+}
+
+// shellCommand returns the script of a `sh -c <script>` command line
+func shellCommand(cmdline string) (string, bool) {
+	fields := strings.Fields(cmdline)
+	if len(fields) < 3 || !shells[path.Base(fields[0])] || fields[1] != "-c" {
+		return "", false
+	}
+	_, script, _ := strings.Cut(cmdline, " -c ")
+	return script, true
+}
+
+// runPartsScript finds the cron.daily/... script among the job's processes
+func runPartsScript(job []model.Process) string {
+	for _, p := range job {
+		for _, arg := range strings.Fields(p.Cmdline) {
+			for _, dir := range runPartsDirs {
+				if strings.HasPrefix(arg, dir+"/") {
+					return arg
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// commandMentions reports whether a command line names dir as a word
+func commandMentions(command, dir string) bool {
+	for _, f := range strings.FieldsFunc(command, func(r rune) bool {
+		return r == ' ' || r == '\t' || r == '(' || r == ')' || r == ';' || r == '"' || r == '\''
+	}) {
+		if strings.TrimSuffix(f, "/") == dir {
+			return true
+		}
+	}
+	return false
+}
+
+func normalizeSpace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func anacronPeriod(period string) string {
+	switch period {
+	case "1":
+		return "daily"
+	case "7":
+		return "weekly"
+	}
+	if strings.HasPrefix(period, "@") {
+		return strings.TrimPrefix(period, "@")
+	}
+	return "every " + period + " days"
+}
+
+// until renders a positive duration as "14h", "25m" or "3d"
+func until(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return "<1m"
+	case d < time.Hour:
+		return strconv.Itoa(int(d.Minutes())) + "m"
+	case d < 48*time.Hour:
+		return strconv.Itoa(int(d.Hours())) + "h"
+	default:
+		return strconv.Itoa(int(d.Hours()/24)) + "d"
+	}
+}
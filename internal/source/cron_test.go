@@ -0,0 +1,106 @@
+package source
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+func cronRoot(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+	writeFixture(t, root, "etc/crontab", "SHELL=/bin/sh\n\n25 6 * * * root test -x /usr/sbin/anacron || { cd / && run-parts --report /etc/cron.daily; }\n")
+	writeFixture(t, root, "etc/cron.d/backup", "# nightly backup\n0 3 * * * root /usr/local/bin/backup.sh --full\n")
+	writeFixture(t, root, "etc/cron.d/.placeholder", "0 3 * * * root /usr/local/bin/backup.sh --full\n")
+	writeFixture(t, root, "var/spool/cron/crontabs/alice", "*/10 * * * * cd ~/site && ./deploy.sh\n")
+	writeFixture(t, root, "etc/anacrontab", "SHELL=/bin/sh\n1\t5\tcron.daily\trun-parts --report /etc/cron.daily\n")
+	writeFixture(t, root, "var/spool/anacron/cron.daily", "20261019\n")
+	return root
+}
+
+func TestExplainCronJob(t *testing.T) {
+	root := cronRoot(t)
+	now := time.Date(2026, 10, 19, 13, 7, 0, 0, time.Local)
+
+	tests := []struct {
+		name   string
+		daemon string
+		job    []model.Process
+		want   map[string]string
+		file   string
+	}{
+		{
+			name:   "sh -c",
+			daemon: "cron",
+			job: []model.Process{
+				{Command: "sh", Cmdline: "/bin/sh -c /usr/local/bin/backup.sh  --full", User: "root"},
+				{Command: "backup.sh", Cmdline: "/bin/bash /usr/local/bin/backup.sh --full", User: "root"},
+			},
+			file: "/etc/cron.d/backup",
+			want: map[string]string{"cron_line": "2", "schedule": "0 3 * * *", "cron_user": "root", "next_run": "2026-10-20 03:00 (in 13h)"},
+		},
+		{
+			name:   "user crontab",
+			daemon: "cron",
+			job:    []model.Process{{Command: "sh", Cmdline: "/bin/sh -c cd ~/site && ./deploy.sh", User: "alice"}},
+			file:   "/var/spool/cron/crontabs/alice",
+			want:   map[string]string{"cron_line": "1", "cron_user": "alice", "next_run": "2026-10-19 13:10 (in 3m)"},
+		},
+		{
+			name:   "exec'd in place of the shell",
+			daemon: "cron",
+			job:    []model.Process{{Command: "backup.sh", Cmdline: "/usr/local/bin/backup.sh --full", User: "root"}},
+			file:   "/etc/cron.d/backup",
+			want:   map[string]string{"cron_line": "2"},
+		},
+		{
+			name:   "run-parts from crontab",
+			daemon: "cron",
+			job: []model.Process{
+				{Command: "sh", Cmdline: "/bin/sh -c test -x /usr/sbin/anacron || { cd / && run-parts --report /etc/cron.daily; }"},
+				{Command: "run-parts", Cmdline: "run-parts --report /etc/cron.daily"},
+				{Command: "logrotate", Cmdline: "/bin/sh /etc/cron.daily/logrotate"},
+			},
+			file: "/etc/crontab",
+			want: map[string]string{"cron_line": "3", "script": "/etc/cron.daily/logrotate"},
+		},
+		{
+			name:   "anacron",
+			daemon: "anacron",
+			job: []model.Process{
+				{Command: "sh", Cmdline: "/bin/sh -c run-parts --report /etc/cron.daily"},
+				{Command: "run-parts", Cmdline: "run-parts --report /etc/cron.daily"},
+				{Command: "man-db", Cmdline: "/bin/sh /etc/cron.daily/man-db"},
+			},
+			file: "/etc/anacrontab",
+			want: map[string]string{"schedule": "daily (anacron job cron.daily)", "script": "/etc/cron.daily/man-db", "next_run": "2026-10-20"},
+		},
+		{
+			name:   "no matching entry",
+			daemon: "cron",
+			job:    []model.Process{{Command: "sh", Cmdline: "/bin/sh -c /opt/other"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src := &model.Source{Type: model.SourceCron, Name: tt.daemon}
+			explainCronJob(root, src, tt.job, now)
+			if src.UnitFile != tt.file {
+				t.Fatalf("UnitFile = %q, want %q (details %v)", src.UnitFile, tt.file, src.Details)
+			}
+			for k, v := range tt.want {
+				if src.Details[k] != v {
+					t.Errorf("%s = %q, want %q", k, src.Details[k], v)
+				}
+			}
+		})
+	}
+}
+
+func TestDetectCronSkipsDaemon(t *testing.T) {
+	ancestry := []model.Process{{PID: 1, Command: "systemd"}, {PID: 700, Command: "cron"}}
+	if src := detectCron(ancestry); src != nil {
+		t.Fatalf("cron daemon itself detected as a cron job: %+v", src)
+	}
+}
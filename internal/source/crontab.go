@@ -0,0 +1,413 @@
+package source
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronEntry is one job line of a crontab or of anacron's anacrontab
+type cronEntry struct {
+	File     string
+	Line     int
+	Schedule string // "0 3 * * *", "@daily", or anacron's period in days
+	User     string // owner of the job; "" when the file does not say
+	Command  string // as written, without the %-separated stdin section
+	JobID    string // anacron job identifier
+	Anacron  bool
+	Location *time.Location // CRON_TZ= in effect for the entry
+}
+
+// systemCrontabs hold entries with a user field
+var systemCrontabs = []string{"/etc/crontab", "/etc/cron.d"}
+
+// cronSpoolDirs hold per-user crontabs named after their owner: Debian, SUSE,
+// cronie and the BSDs each use a different one
+var cronSpoolDirs = []string{
+	"/var/spool/cron/crontabs",
+	"/var/spool/cron/tabs",
+	"/var/spool/cron",
+	"/var/cron/tabs",
+}
+
+const anacrontabPath = "/etc/anacrontab"
+
+// loadCronEntries parses every crontab cron and anacron read below root
+func loadCronEntries(root string) []cronEntry {
+	var entries []cronEntry
+	for _, p := range systemCrontabs {
+		for _, file := range crontabFiles(root, p) {
+			if data, err := os.ReadFile(filepath.Join(root, file)); err == nil {
+				entries = append(entries, parseCrontab(string(data), file, "")...)
+			}
+		}
+	}
+	for _, dir := range cronSpoolDirs {
+		for _, file := range crontabFiles(root, dir) {
+			if data, err := os.ReadFile(filepath.Join(root, file)); err == nil {
+				entries = append(entries, parseCrontab(string(data), file, path.Base(file))...)
+			}
+		}
+	}
+	if data, err := os.ReadFile(filepath.Join(root, anacrontabPath)); err == nil {
+		entries = append(entries, parseAnacrontab(string(data), anacrontabPath)...)
+	}
+	return entries
+}
+
+// crontabFiles expands a crontab path to itself or, for a directory, the
+// regular files in it that cron reads (no dotfiles, backups or package leftovers)
+func crontabFiles(root, p string) []string {
+	info, err := os.Stat(filepath.Join(root, p))
+	if err != nil {
+		return nil
+	}
+	if !info.IsDir() {
+		return []string{p}
+	}
+	dirents, err := os.ReadDir(filepath.Join(root, p))
+	if err != nil {
+		return nil
+	}
+	var files []string
+	for _, e := range dirents {
+		name := e.Name()
+		if !e.Type().IsRegular() || strings.HasPrefix(name, ".") || strings.HasSuffix(name, "~") ||
+			strings.Contains(name, ".dpkg-") || strings.HasSuffix(name, ".rpmsave") || strings.HasSuffix(name, ".rpmnew") {
+			continue
+		}
+		files = append(files, path.Join(p, name))
+	}
+	return files
+}
+
+// parseCrontab parses a crontab. owner is the user of a spool crontab; system
+// crontabs (owner "") carry a user field between schedule and command.
+func parseCrontab(data, file, owner string) []cronEntry {
+	var entries []cronEntry
+	var loc *time.Location
+	lineNo := 0
+	for line := range strings.Lines(data) {
+		lineNo++
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if key, value, ok := cronEnvAssignment(line); ok {
+			if key == "CRON_TZ" {
+				loc, _ = time.LoadLocation(value)
+			}
+			continue
+		}
+
+		scheduleFields := 5
+		if strings.HasPrefix(line, "@") {
+			scheduleFields = 1
+		}
+		want := scheduleFields
+		if owner == "" {
+			want++
+		}
+		fields, rest := splitCronFields(line, want)
+		if len(fields) < want || rest == "" {
+			continue
+		}
+		e := cronEntry{
+			File:     file,
+			Line:     lineNo,
+			Schedule: strings.Join(fields[:scheduleFields], " "),
+			User:     owner,
+			Command:  cronCommand(rest),
+			Location: loc,
+		}
+		if owner == "" {
+			e.User = fields[scheduleFields]
+		}
+		entries = append(entries, e)
+	}
+	return entries
+}
+
+// parseAnacrontab parses "period delay job-identifier command" lines
+func parseAnacrontab(data, file string) []cronEntry {
+	var entries []cronEntry
+	lineNo := 0
+	for line := range strings.Lines(data) {
+		lineNo++
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if _, _, ok := cronEnvAssignment(line); ok {
+			continue
+		}
+		fields, rest := splitCronFields(line, 3)
+		if len(fields) < 3 || rest == "" {
+			continue
+		}
+		entries = append(entries, cronEntry{
+			File:     file,
+			Line:     lineNo,
+			Schedule: fields[0],
+			User:     "root",
+			Command:  rest,
+			JobID:    fields[2],
+			Anacron:  true,
+		})
+	}
+	return entries
+}
+
+// cronEnvAssignment recognizes "NAME=value" lines, which set the environment
+// of the jobs that follow
+func cronEnvAssignment(line string) (key, value string, ok bool) {
+	key, value, ok = strings.Cut(line, "=")
+	if !ok {
+		return "", "", false
+	}
+	key = strings.TrimSpace(key)
+	if key == "" || strings.ContainsAny(key, " \t*/,@") || (key[0] >= '0' && key[0] <= '9') {
+		return "", "", false
+	}
+	return key, strings.Trim(strings.TrimSpace(value), `"'`), true
+}
+
+// splitCronFields splits off n whitespace-separated fields and returns the
+// rest of the line with its spacing intact
+func splitCronFields(line string, n int) ([]string, string) {
+	var fields []string
+	rest := line
+	for len(fields) < n {
+		rest = strings.TrimLeft(rest, " \t")
+		if rest == "" {
+			break
+		}
+		end := strings.IndexAny(rest, " \t")
+		if end < 0 {
+			end = len(rest)
+		}
+		fields = append(fields, rest[:end])
+		rest = rest[end:]
+	}
+	return fields, strings.TrimSpace(rest)
+}
+
+// cronCommand drops the stdin section after the first unescaped % and
+// unescapes \%, giving the string cron passes to `sh -c`
+func cronCommand(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		switch {
+		case s[i] == '\\' && i+1 < len(s) && s[i+1] == '%':
+			b.WriteByte('%')
+			i++
+		case s[i] == '%':
+			return strings.TrimSpace(b.String())
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return strings.TrimSpace(b.String())
+}
+
+// cronSchedule is a parsed five-field schedule as bit sets per field
+type cronSchedule struct {
+	minute, hour, dom, month, dow uint64
+	domStar, dowStar              bool
+}
+
+var errCronReboot = errors.New("@reboot has no next run")
+
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+var cronMonthNames = []string{"jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec"}
+var cronDayNames = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+
+func parseCronSchedule(spec string) (cronSchedule, error) {
+	if spec == "@reboot" {
+		return cronSchedule{}, errCronReboot
+	}
+	if macro, ok := cronMacros[spec]; ok {
+		spec = macro
+	}
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return cronSchedule{}, fmt.Errorf("schedule %q does not have 5 fields", spec)
+	}
+
+	var s cronSchedule
+	var err error
+	if s.minute, err = parseCronField(fields[0], 0, 59, nil); err != nil {
+		return s, err
+	}
+	if s.hour, err = parseCronField(fields[1], 0, 23, nil); err != nil {
+		return s, err
+	}
+	if s.dom, err = parseCronField(fields[2], 1, 31, nil); err != nil {
+		return s, err
+	}
+	if s.month, err = parseCronField(fields[3], 1, 12, cronMonthNames); err != nil {
+		return s, err
+	}
+	if s.dow, err = parseCronField(fields[4], 0, 7, cronDayNames); err != nil {
+		return s, err
+	}
+	if s.dow&(1<<7) != 0 {
+		s.dow |= 1 // 7 is another name for Sunday
+	}
+	s.domStar = strings.HasPrefix(fields[2], "*")
+	s.dowStar = strings.HasPrefix(fields[4], "*")
+	return s, nil
+}
+
+// parseCronField parses lists of values, ranges and steps ("1,5-10/2,*/15")
+func parseCronField(field string, lo, hi int, names []string) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		rng, stepStr, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepStr)
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("bad step in %q", part)
+			}
+			step = n
+		}
+
+		start, end := lo, hi
+		if rng != "*" {
+			a, b, isRange := strings.Cut(rng, "-")
+			var err error
+			if start, err = cronValue(a, lo, names); err != nil {
+				return 0, err
+			}
+			end = start
+			if isRange {
+				if end, err = cronValue(b, lo, names); err != nil {
+					return 0, err
+				}
+			} else if hasStep {
+				end = hi // "5/10" means from 5 to the maximum
+			}
+		}
+		if start < lo || end > hi || start > end {
+			return 0, fmt.Errorf("%q is out of range", part)
+		}
+		for v := start; v <= end; v += step {
+			bits |= 1 << v
+		}
+	}
+	return bits, nil
+}
+
+func cronValue(s string, lo int, names []string) (int, error) {
+	for i, name := range names {
+		if strings.EqualFold(s, name) {
+			return i + lo, nil
+		}
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("bad value %q", s)
+	}
+	return n, nil
+}
+
+func (s cronSchedule) dayMatches(t time.Time) bool {
+	dom := s.dom&(1<<t.Day()) != 0
+	dow := s.dow&(1<<int(t.Weekday())) != 0
+	// A * in either day field means both must match; with both restricted,
+	// cron runs on days matching either
+	if s.domStar || s.dowStar {
+		return dom && dow
+	}
+	return dom || dow
+}
+
+// next returns the first minute after from that the schedule fires
+func (s cronSchedule) next(from time.Time) (time.Time, bool) {
+	loc := from.Location()
+	t := from.Truncate(time.Minute).Add(time.Minute)
+	limit := from.AddDate(5, 0, 0)
+	for t.Before(limit) {
+		switch {
+		case s.month&(1<<int(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+		case !s.dayMatches(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+		case s.hour&(1<<t.Hour()) == 0:
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+		case s.minute&(1<<t.Minute()) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// nextCronRun returns when the entry runs next after now
+func nextCronRun(root string, e cronEntry, now time.Time) (time.Time, bool) {
+	if e.Anacron {
+		return nextAnacronRun(root, e, now)
+	}
+	s, err := parseCronSchedule(e.Schedule)
+	if err != nil {
+		return time.Time{}, false
+	}
+	if e.Location != nil {
+		now = now.In(e.Location)
+	}
+	return s.next(now)
+}
+
+// anacronSpool holds one timestamp file per job with the day it last ran
+const anacronSpool = "/var/spool/anacron"
+
+// nextAnacronRun returns the day an anacron job becomes due again: its period
+// after the last run recorded in the spool, or today when it never ran.
+func nextAnacronRun(root string, e cronEntry, now time.Time) (time.Time, bool) {
+	data, err := os.ReadFile(filepath.Join(root, anacronSpool, e.JobID))
+	if err != nil {
+		return time.Time{}, false
+	}
+	last, err := time.ParseInLocation("20060102", strings.TrimSpace(string(data)), now.Location())
+	if err != nil {
+		return time.Time{}, false
+	}
+	var due time.Time
+	switch e.Schedule {
+	case "@daily":
+		due = last.AddDate(0, 0, 1)
+	case "@weekly":
+		due = last.AddDate(0, 0, 7)
+	case "@monthly":
+		due = last.AddDate(0, 1, 0)
+	case "@yearly", "@annually":
+		due = last.AddDate(1, 0, 0)
+	default:
+		days, err := strconv.Atoi(e.Schedule)
+		if err != nil {
+			return time.Time{}, false
+		}
+		due = last.AddDate(0, 0, days)
+	}
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	if due.Before(today) {
+		due = today
+	}
+	return due, true
+}
@@ -0,0 +1,89 @@
+package source
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCrontab(t *testing.T) {
+	data := `# m h dom mon dow user command
+SHELL=/bin/sh
+CRON_TZ=UTC
+17 *	* * *	root    cd / && run-parts --report /etc/cron.hourly
+0 3 * * * backup /usr/local/bin/backup.sh --full % line1%line2
+@reboot root /usr/bin/warmup
+*/5 * * * * root echo 100\% done
+broken line
+`
+	entries := parseCrontab(data, "/etc/crontab", "")
+	if len(entries) != 4 {
+		t.Fatalf("got %d entries: %+v", len(entries), entries)
+	}
+	e := entries[1]
+	if e.Line != 5 || e.Schedule != "0 3 * * *" || e.User != "backup" || e.Command != "/usr/local/bin/backup.sh --full" {
+		t.Errorf("unexpected entry: %+v", e)
+	}
+	if e.Location == nil || e.Location.String() != "UTC" {
+		t.Errorf("CRON_TZ not applied: %v", e.Location)
+	}
+	if entries[2].Schedule != "@reboot" || entries[2].Command != "/usr/bin/warmup" {
+		t.Errorf("unexpected @reboot entry: %+v", entries[2])
+	}
+	if entries[3].Command != "echo 100% done" {
+		t.Errorf("escaped %% not unescaped: %q", entries[3].Command)
+	}
+
+	user := parseCrontab("30 2 * * 1-5 /home/alice/bin/sync\n", "/var/spool/cron/crontabs/alice", "alice")
+	if len(user) != 1 || user[0].User != "alice" || user[0].Command != "/home/alice/bin/sync" {
+		t.Errorf("unexpected user entry: %+v", user)
+	}
+}
+
+func TestCronScheduleNext(t *testing.T) {
+	from := time.Date(2026, 10, 19, 13, 7, 30, 0, time.UTC) // a Monday
+	tests := []struct {
+		spec string
+		want string
+	}{
+		{"0 3 * * *", "2026-10-20 03:00"},
+		{"*/15 * * * *", "2026-10-19 13:15"},
+		{"17 * * * *", "2026-10-19 13:17"},
+		{"0 9 * * 1-5", "2026-10-20 09:00"},
+		{"0 0 * * sun", "2026-10-25 00:00"},
+		{"0 0 * * 7", "2026-10-25 00:00"},
+		{"0 0 1 jan *", "2027-01-01 00:00"},
+		{"0 12 13 * 5", "2026-10-23 12:00"}, // day 13 or any Friday
+		{"0 0 31 2 *", ""},
+		{"@weekly", "2026-10-25 00:00"},
+		{"@reboot", ""},
+		{"61 * * * *", ""},
+	}
+	for _, tt := range tests {
+		got := ""
+		if s, err := parseCronSchedule(tt.spec); err == nil {
+			if next, ok := s.next(from); ok {
+				got = next.Format("2006-01-02 15:04")
+			}
+		}
+		if got != tt.want {
+			t.Errorf("next(%q) = %q, want %q", tt.spec, got, tt.want)
+		}
+	}
+}
+
+func TestNextAnacronRun(t *testing.T) {
+	root := t.TempDir()
+	writeFixture(t, root, "var/spool/anacron/cron.weekly", "20261015\n")
+	writeFixture(t, root, "var/spool/anacron/cron.daily", "20261001\n")
+	now := time.Date(2026, 10, 19, 13, 0, 0, 0, time.UTC)
+
+	weekly := cronEntry{Schedule: "7", JobID: "cron.weekly", Anacron: true}
+	if next, ok := nextAnacronRun(root, weekly, now); !ok || next.Format("2006-01-02") != "2026-10-22" {
+		t.Errorf("weekly next = %v, %v", next, ok)
+	}
+	// Overdue jobs run the next time anacron starts, i.e. today
+	daily := cronEntry{Schedule: "1", JobID: "cron.daily", Anacron: true}
+	if next, ok := nextAnacronRun(root, daily, now); !ok || next.Format("2006-01-02") != "2026-10-19" {
+		t.Errorf("daily next = %v, %v", next, ok)
+	}
+}
@@ -1,6 +1,7 @@
 package source
 
 import (
+	"fmt"
 	"sort"
 	"strings"
 	"time"
@@ -35,9 +36,23 @@ var (
 func Detect(ancestry []model.Process) model.Source {
 	// Detection order prioritizes platform-specific init systems
 	// over generic supervisor detection to avoid false positives
+	if src := detectKernel(ancestry); src != nil {
+		return *src
+	}
 	if src := detectContainer(ancestry); src != nil {
 		return *src
 	}
+	// Cron and at jobs run through a shell under a daemon that is itself a
+	// service, so they must be recognized before either
+	if src := detectCron(ancestry); src != nil {
+		return *src
+	}
+	if src := detectAt(ancestry); src != nil {
+		return *src
+	}
+	if src := detectMultiplexer(ancestry); src != nil {
+		return *src
+	}
 	if src := detectShell(ancestry); src != nil {
 		return *src
 	}
@@ -53,9 +68,6 @@ func Detect(ancestry []model.Process) model.Source {
 	if src := detectSupervisor(ancestry); src != nil {
 		return *src
 	}
-	if src := detectCron(ancestry); src != nil {
-		return *src
-	}
 	if src := detectWindowsService(ancestry); src != nil {
 		return *src
 	}
@@ -68,6 +80,41 @@ func Detect(ancestry []model.Process) model.Source {
 	}
 }
 
+// restartLoopWindow is how recently a container that restarted repeatedly
+// must have started again to count as looping
+const restartLoopWindow = 10 * time.Minute
+
+// containerWarnings reports a container without a healthcheck, one failing
+// its healthcheck, and one the engine keeps restarting
+func containerWarnings(c *model.ContainerInfo) []string {
+	var w []string
+	switch {
+	case !c.Healthcheck:
+		w = append(w, "Container has no healthcheck defined")
+	case c.Health == "unhealthy":
+		msg := "Container is unhealthy"
+		if c.FailingStreak > 0 {
+			msg += fmt.Sprintf(" (%d consecutive failed checks)", c.FailingStreak)
+		}
+		if c.HealthOutput != "" {
+			msg += ": " + c.HealthOutput
+		}
+		w = append(w, msg)
+	}
+
+	// RestartCount covers the container's whole life, so many restarts only
+	// mean a loop when the latest one was recent
+	recent := !c.StartedAt.IsZero() && time.Since(c.StartedAt) < restartLoopWindow
+	if (c.Restarting && c.RestartCount > 0) || (c.RestartCount > 5 && recent) {
+		policy := c.RestartPolicy
+		if policy == "" {
+			policy = "no"
+		}
+		w = append(w, fmt.Sprintf("Container is restart-looping (%d restarts, restart policy %s)", c.RestartCount, policy))
+	}
+	return w
+}
+
 // env suspicious warnings returns warnings for known env based library injection patterns
 func envSuspiciousWarnings(env []string) []string {
 	matched := make([]bool, len(envVarRules))
@@ -157,11 +204,12 @@ func Warnings(p []model.Process) []string {
 		w = append(w, "Process is listening on a public interface")
 	}
 
-	if last.User == "root" {
+	// Kernel threads always run as root from / without a supervisor
+	if last.User == "root" && !last.KernelThread {
 		w = append(w, "Process is running as root")
 	}
 
-	if Detect(p).Type == model.SourceUnknown {
+	if !last.KernelThread && Detect(p).Type == model.SourceUnknown {
 		w = append(w, "No known supervisor or service manager detected")
 	}
 
@@ -172,13 +220,12 @@ func Warnings(p []model.Process) []string {
 
 	// Warn if working dir is suspicious
 	suspiciousDirs := map[string]bool{"/": true, "/tmp": true, "/var/tmp": true}
-	if suspiciousDirs[last.WorkingDir] {
+	if suspiciousDirs[last.WorkingDir] && !last.KernelThread {
 		w = append(w, "Process is running from a suspicious working directory: "+last.WorkingDir)
 	}
 
-	// Warn if container and no healthcheck (placeholder, as healthcheck not detected)
-	if last.Container != "" {
-		w = append(w, "No healthcheck detected for container (best effort)")
+	if c := last.ContainerInfo; c != nil {
+		w = append(w, containerWarnings(c)...)
 	}
 
 	// Warn if service name and process name mismatch
@@ -191,6 +238,16 @@ func Warnings(p []model.Process) []string {
 		w = append(w, "Process is running from a deleted binary (potential library injection or pending update)")
 	}
 
+	// Warn if the binary was built from an older checkout than the one on disk
+	if g := last.Git; g != nil && g.BinaryInRepo && !g.BinaryNewer && !g.HeadTime.IsZero() {
+		w = append(w, "Binary is older than the checked-out git HEAD (process may be running a stale build)")
+	}
+
+	// Warn if traced by a process owned by someone else
+	if last.TracerPID > 0 && last.TracerUser != "" && last.TracerUser != last.User {
+		w = append(w, fmt.Sprintf("Process is being traced by pid %d owned by a different user (%s)", last.TracerPID, last.TracerUser))
+	}
+
 	// Include warnings based on suspicious env variables
 	w = append(w, envSuspiciousWarnings(last.Env)...)
 
@@ -212,3 +212,139 @@ func TestEnrichSocketInfo(t *testing.T) {
 		}
 	}
 }
+
+func TestWarningsDetectsForeignTracer(t *testing.T) {
+	p := []model.Process{
+		{PID: 999999, Command: "pm2", Cmdline: "pm2"},
+		{
+			PID:        123,
+			Command:    "node",
+			StartedAt:  time.Now(),
+			User:       "bob",
+			WorkingDir: "/home/bob",
+			TracerPID:  456,
+			TracerUser: "mallory",
+		},
+	}
+
+	warnings := Warnings(p)
+	want := "Process is being traced by pid 456 owned by a different user (mallory)"
+	if !slices.Contains(warnings, want) {
+		t.Fatalf("expected tracer warning %q, got: %v", want, warnings)
+	}
+
+	p[1].TracerUser = "bob"
+	for _, w := range Warnings(p) {
+		if strings.Contains(w, "traced") {
+			t.Fatalf("did not expect tracer warning for same-user tracer, got: %v", w)
+		}
+	}
+}
+
+func TestWarningsSuppressedForKernelThreads(t *testing.T) {
+	p := []model.Process{
+		{PID: 2, Command: "kthreadd", User: "root", WorkingDir: "/", KernelThread: true},
+		{
+			PID:          12,
+			PPID:         2,
+			Command:      "kworker/u4:0-events_unbound",
+			User:         "root",
+			WorkingDir:   "/",
+			StartedAt:    time.Now(),
+			KernelThread: true,
+		},
+	}
+
+	if warnings := Warnings(p); len(warnings) != 0 {
+		t.Fatalf("expected no warnings for kernel thread, got: %v", warnings)
+	}
+
+	// Security warnings still apply
+	p[1].ExeDeleted = true
+	p[1].TracerPID, p[1].TracerUser = 4242, "mallory"
+	warnings := Warnings(p)
+	if len(warnings) != 2 || !strings.Contains(warnings[0], "deleted binary") || !strings.Contains(warnings[1], "traced by pid 4242") {
+		t.Fatalf("expected deleted binary and tracer warnings, got: %v", warnings)
+	}
+}
+
+func TestWarningsDetectsStaleGitBinary(t *testing.T) {
+	p := []model.Process{
+		{
+			PID:        123,
+			Command:    "api",
+			StartedAt:  time.Now(),
+			User:       "bob",
+			WorkingDir: "/home/bob/api",
+			Git: &model.GitInfo{
+				Root:         "/home/bob/api",
+				HeadTime:     time.Now(),
+				BinaryInRepo: true,
+			},
+		},
+	}
+
+	want := "Binary is older than the checked-out git HEAD (process may be running a stale build)"
+	if !slices.Contains(Warnings(p), want) {
+		t.Fatalf("expected stale binary warning, got: %v", Warnings(p))
+	}
+
+	p[0].Git.BinaryNewer = true
+	if slices.Contains(Warnings(p), want) {
+		t.Fatal("did not expect stale binary warning for a fresh build")
+	}
+}
+
+func TestWarningsContainerHealth(t *testing.T) {
+	tests := []struct {
+		name string
+		info *model.ContainerInfo
+		want []string
+	}{
+		{
+			name: "healthy",
+			info: &model.ContainerInfo{Healthcheck: true, Health: "healthy", RestartPolicy: "always", RestartCount: 1},
+		},
+		{
+			name: "no healthcheck",
+			info: &model.ContainerInfo{},
+			want: []string{"Container has no healthcheck defined"},
+		},
+		{
+			name: "unhealthy",
+			info: &model.ContainerInfo{Healthcheck: true, Health: "unhealthy", FailingStreak: 4, HealthOutput: "curl: (7) Failed to connect to localhost port 80"},
+			want: []string{"Container is unhealthy (4 consecutive failed checks): curl: (7) Failed to connect to localhost port 80"},
+		},
+		{
+			name: "restart loop",
+			info: &model.ContainerInfo{Healthcheck: true, Health: "starting", RestartPolicy: "unless-stopped", RestartCount: 12, Restarting: true},
+			want: []string{"Container is restart-looping (12 restarts, restart policy unless-stopped)"},
+		},
+		{
+			name: "recent restarts",
+			info: &model.ContainerInfo{Healthcheck: true, Health: "healthy", RestartPolicy: "always", RestartCount: 7, StartedAt: time.Now().Add(-30 * time.Second)},
+			want: []string{"Container is restart-looping (7 restarts, restart policy always)"},
+		},
+		{
+			name: "restarts long ago",
+			info: &model.ContainerInfo{Healthcheck: true, Health: "healthy", RestartPolicy: "always", RestartCount: 7, StartedAt: time.Now().Add(-90 * 24 * time.Hour)},
+		},
+		{
+			name: "no engine data",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := []model.Process{{PID: 4242, Command: "api", User: "app", StartedAt: time.Now(), Container: "docker: api", ContainerInfo: tt.info}}
+			var got []string
+			for _, w := range Warnings(p) {
+				if strings.HasPrefix(w, "Container") || strings.Contains(w, "healthcheck") {
+					got = append(got, w)
+				}
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Fatalf("container warnings = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
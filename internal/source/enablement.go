@@ -0,0 +1,319 @@
+package source
+
+import (
+	"bufio"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// unitPaths are the directories a systemd instance loads units and presets from
+type unitPaths struct {
+	units   []string // unit directories in precedence order
+	presets []string // *.preset directories; a file name in an earlier directory masks later ones
+	home    string   // for a systemd --user instance, the user's home directory
+}
+
+// systemUnitPaths are the system manager's directories
+var systemUnitPaths = unitPaths{
+	units: []string{
+		"/etc/systemd/system",
+		"/run/systemd/system",
+		"/usr/local/lib/systemd/system",
+		"/usr/lib/systemd/system",
+		"/lib/systemd/system",
+	},
+	presets: []string{
+		"/etc/systemd/system-preset",
+		"/run/systemd/system-preset",
+		"/usr/local/lib/systemd/system-preset",
+		"/usr/lib/systemd/system-preset",
+		"/lib/systemd/system-preset",
+	},
+}
+
+// userUnitPaths are the directories of a systemd --user instance for home
+func userUnitPaths(home string) unitPaths {
+	return unitPaths{
+		units: []string{
+			path.Join(home, ".config/systemd/user"),
+			"/etc/systemd/user",
+			"/run/systemd/user",
+			path.Join(home, ".local/share/systemd/user"),
+			"/usr/local/lib/systemd/user",
+			"/usr/lib/systemd/user",
+		},
+		presets: []string{
+			"/etc/systemd/user-preset",
+			"/run/systemd/user-preset",
+			"/usr/local/lib/systemd/user-preset",
+			"/usr/lib/systemd/user-preset",
+		},
+		home: home,
+	}
+}
+
+// findUnitFile returns the first unit file named unit in the search path, the
+// one systemd loads when no bus is available to ask for FragmentPath
+func findUnitFile(root string, paths unitPaths, unit string) string {
+	for _, dir := range paths.units {
+		if info, err := os.Stat(filepath.Join(root, dir, unit)); err == nil && !info.IsDir() {
+			return path.Join(dir, unit)
+		}
+	}
+	return ""
+}
+
+// unitLink is a symlink in a <target>.wants/ or <target>.requires/ directory
+type unitLink struct {
+	Target string
+	Kind   string // wants or requires
+	Path   string
+}
+
+// unitGraph holds Wants=/Requires= edges between units, both from the
+// .wants/.requires symlinks created by `systemctl enable` and from unit files.
+type unitGraph struct {
+	edges         map[string][]string   // unit → units it pulls in
+	links         map[string][]unitLink // unit → enablement symlinks pointing at it
+	defaultTarget string
+}
+
+func loadUnitGraph(root string, paths unitPaths) *unitGraph {
+	g := &unitGraph{edges: map[string][]string{}, links: map[string][]unitLink{}}
+	seenFile := map[string]bool{}
+
+	for _, dir := range paths.units {
+		entries, err := os.ReadDir(filepath.Join(root, dir))
+		if err != nil {
+			continue
+		}
+		for _, e := range entries {
+			name := e.Name()
+			full := filepath.Join(root, dir, name)
+
+			if kind, target, ok := dependencyDir(name); ok {
+				children, _ := os.ReadDir(full)
+				for _, c := range children {
+					g.addEdge(target, c.Name())
+					g.links[c.Name()] = append(g.links[c.Name()], unitLink{
+						Target: target,
+						Kind:   kind,
+						Path:   path.Join(dir, name, c.Name()),
+					})
+				}
+				continue
+			}
+
+			if name == "default.target" && g.defaultTarget == "" {
+				if dest, err := os.Readlink(full); err == nil {
+					g.defaultTarget = filepath.Base(dest)
+				}
+			}
+
+			if !isUnitName(name) || seenFile[name] {
+				continue
+			}
+			seenFile[name] = true
+			for _, dep := range unitFileDependencies(full) {
+				g.addEdge(name, dep)
+			}
+		}
+	}
+	if g.defaultTarget == "" {
+		g.defaultTarget = "default.target"
+	}
+	return g
+}
+
+func dependencyDir(name string) (kind, target string, ok bool) {
+	if t, found := strings.CutSuffix(name, ".wants"); found {
+		return "wants", t, true
+	}
+	if t, found := strings.CutSuffix(name, ".requires"); found {
+		return "requires", t, true
+	}
+	return "", "", false
+}
+
+func isUnitName(name string) bool {
+	switch path.Ext(name) {
+	case ".service", ".socket", ".target", ".timer", ".path", ".mount", ".slice", ".scope", ".device", ".swap", ".automount":
+		return true
+	}
+	return false
+}
+
+func (g *unitGraph) addEdge(from, to string) {
+	if !slices.Contains(g.edges[from], to) {
+		g.edges[from] = append(g.edges[from], to)
+	}
+}
+
+// unitFileDependencies reads Wants= and Requires= from a unit's [Unit] section
+func unitFileDependencies(file string) []string {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+
+	var deps []string
+	section := ""
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "[") {
+			section = line
+			continue
+		}
+		if section != "[Unit]" {
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		switch strings.TrimSpace(key) {
+		case "Wants", "Requires":
+			deps = append(deps, strings.Fields(value)...)
+		}
+	}
+	return deps
+}
+
+// bootPath finds the chain of units from the default target down to unit
+func (g *unitGraph) bootPath(unit string) []string {
+	start := g.defaultTarget
+	prev := map[string]string{start: ""}
+	queue := []string{start}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		if cur == unit {
+			var chain []string
+			for n := unit; n != ""; n = prev[n] {
+				chain = append(chain, n)
+			}
+			slices.Reverse(chain)
+			if start != "default.target" {
+				chain = append([]string{"default.target"}, chain...)
+			}
+			return chain
+		}
+		for _, next := range g.edges[cur] {
+			if _, seen := prev[next]; !seen {
+				prev[next] = cur
+				queue = append(queue, next)
+			}
+		}
+	}
+	return nil
+}
+
+// pulledInBy lists units whose unit files declare Wants=/Requires= on unit
+func (g *unitGraph) pulledInBy(unit string) []string {
+	linked := map[string]bool{}
+	for _, l := range g.links[unit] {
+		linked[l.Target] = true
+	}
+	var out []string
+	for from, tos := range g.edges {
+		if !linked[from] && slices.Contains(tos, unit) {
+			out = append(out, from)
+		}
+	}
+	slices.Sort(out)
+	return out
+}
+
+// presetEnables reports whether the vendor preset policy enables unit
+func presetEnables(root string, paths unitPaths, unit string) bool {
+	files := map[string]string{}
+	var names []string
+	for _, dir := range paths.presets {
+		matches, _ := filepath.Glob(filepath.Join(root, dir, "*.preset"))
+		for _, m := range matches {
+			base := filepath.Base(m)
+			if _, ok := files[base]; !ok {
+				files[base] = m
+				names = append(names, base)
+			}
+		}
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		data, err := os.ReadFile(files[name])
+		if err != nil {
+			continue
+		}
+		for line := range strings.Lines(string(data)) {
+			fields := strings.Fields(line)
+			if len(fields) < 2 || strings.HasPrefix(fields[0], "#") {
+				continue
+			}
+			if ok, _ := path.Match(fields[1], unit); ok {
+				return fields[0] == "enable"
+			}
+		}
+	}
+	return false
+}
+
+// explainEnablement fills Source.Details with why a unit starts at boot: who
+// enabled it, the targets that want it, and the path from default.target.
+func explainEnablement(root string, paths unitPaths, unit string, details map[string]string) {
+	g := loadUnitGraph(root, paths)
+	links := g.links[unit]
+
+	var wantedBy []string
+	for _, l := range links {
+		entry := l.Target
+		if l.Kind == "requires" {
+			entry += " (requires)"
+		}
+		if !slices.Contains(wantedBy, entry) {
+			wantedBy = append(wantedBy, entry)
+		}
+	}
+	pulledBy := g.pulledInBy(unit)
+	for _, u := range pulledBy {
+		wantedBy = append(wantedBy, u+" (unit file)")
+	}
+	if len(wantedBy) > 0 {
+		details["wanted_by"] = strings.Join(wantedBy, ", ")
+	}
+
+	switch {
+	case paths.home != "" && hasLinkUnder(links, paths.home+"/"):
+		details["enabled"] = "enabled by user"
+	case hasLinkUnder(links, "/etc/"):
+		if presetEnables(root, paths, unit) {
+			details["enabled"] = "enabled by vendor preset"
+		} else {
+			details["enabled"] = "enabled by admin"
+		}
+	case hasLinkUnder(links, "/run/"):
+		details["enabled"] = "enabled at runtime"
+	case len(links) > 0:
+		details["enabled"] = "statically enabled by vendor"
+	case len(pulledBy) > 0:
+		details["enabled"] = "static, pulled in by " + strings.Join(pulledBy, ", ")
+	}
+
+	if chain := g.bootPath(unit); len(chain) > 0 {
+		details["boot_path"] = strings.Join(chain, " → ")
+	}
+}
+
+func hasLinkUnder(links []unitLink, prefix string) bool {
+	for _, l := range links {
+		if strings.HasPrefix(l.Path, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,109 @@
+package source
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// unitTree builds a fake root with unit files, enablement symlinks and presets
+func unitTree(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+	lib := "usr/lib/systemd/system"
+	writeFixture(t, root, lib+"/graphical.target", "[Unit]\nDescription=Graphical Interface\nRequires=multi-user.target\nWants=display-manager.service\n")
+	writeFixture(t, root, lib+"/multi-user.target", "[Unit]\nDescription=Multi-User System\nRequires=basic.target\n")
+	writeFixture(t, root, lib+"/basic.target", "[Unit]\nRequires=sysinit.target\nWants=sockets.target timers.target\n")
+	writeFixture(t, root, lib+"/sysinit.target", "[Unit]\nDescription=System Initialization\n")
+	writeFixture(t, root, lib+"/sockets.target", "[Unit]\n")
+	writeFixture(t, root, lib+"/timers.target", "[Unit]\n")
+	writeFixture(t, root, lib+"/nginx.service", "[Unit]\nDescription=nginx\n[Service]\nExecStart=/usr/sbin/nginx\n[Install]\nWantedBy=multi-user.target\n")
+	writeFixture(t, root, lib+"/ssh.service", "[Service]\nExecStart=/usr/sbin/sshd -D\n[Install]\nWantedBy=multi-user.target\n")
+	writeFixture(t, root, lib+"/systemd-journald.service", "[Unit]\nDescription=Journal\n[Service]\nExecStart=/lib/systemd/systemd-journald\n")
+	writeFixture(t, root, lib+"/helper.service", "[Service]\nExecStart=/bin/true\n")
+	writeFixture(t, root, lib+"/api.service", "[Unit]\nWants=helper.service\n[Service]\nExecStart=/srv/api\n")
+	writeFixture(t, root, "usr/lib/systemd/system-preset/90-systemd.preset", "enable ssh.service\nenable getty@.service\n")
+	writeFixture(t, root, "usr/lib/systemd/system-preset/99-default.preset", "disable *\n")
+
+	symlink := func(target, link string) {
+		full := filepath.Join(root, link)
+		if err := os.MkdirAll(filepath.Dir(full), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Symlink(target, full); err != nil {
+			t.Fatal(err)
+		}
+	}
+	symlink("/usr/lib/systemd/system/graphical.target", "etc/systemd/system/default.target")
+	symlink("/usr/lib/systemd/system/nginx.service", "etc/systemd/system/multi-user.target.wants/nginx.service")
+	symlink("/usr/lib/systemd/system/ssh.service", "etc/systemd/system/multi-user.target.wants/ssh.service")
+	symlink("../systemd-journald.service", lib+"/sysinit.target.wants/systemd-journald.service")
+	return root
+}
+
+func TestExplainEnablement(t *testing.T) {
+	root := unitTree(t)
+
+	tests := []struct {
+		unit     string
+		enabled  string
+		wantedBy string
+		bootPath string
+	}{
+		{
+			unit:     "nginx.service",
+			enabled:  "enabled by admin",
+			wantedBy: "multi-user.target",
+			bootPath: "default.target → graphical.target → multi-user.target → nginx.service",
+		},
+		{
+			unit:     "ssh.service",
+			enabled:  "enabled by vendor preset",
+			wantedBy: "multi-user.target",
+			bootPath: "default.target → graphical.target → multi-user.target → ssh.service",
+		},
+		{
+			unit:     "systemd-journald.service",
+			enabled:  "statically enabled by vendor",
+			wantedBy: "sysinit.target",
+			bootPath: "default.target → graphical.target → multi-user.target → basic.target → sysinit.target → systemd-journald.service",
+		},
+		{
+			unit:     "helper.service",
+			enabled:  "static, pulled in by api.service",
+			wantedBy: "api.service (unit file)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.unit, func(t *testing.T) {
+			d := map[string]string{}
+			explainEnablement(root, systemUnitPaths, tt.unit, d)
+			if d["enabled"] != tt.enabled {
+				t.Errorf("enabled = %q, want %q", d["enabled"], tt.enabled)
+			}
+			if d["wanted_by"] != tt.wantedBy {
+				t.Errorf("wanted_by = %q, want %q", d["wanted_by"], tt.wantedBy)
+			}
+			if d["boot_path"] != tt.bootPath {
+				t.Errorf("boot_path = %q, want %q", d["boot_path"], tt.bootPath)
+			}
+		})
+	}
+}
+
+func TestExplainEnablementRuntimeAndRequires(t *testing.T) {
+	root := unitTree(t)
+	link := filepath.Join(root, "run/systemd/system/multi-user.target.requires/api.service")
+	if err := os.MkdirAll(filepath.Dir(link), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink("/usr/lib/systemd/system/api.service", link); err != nil {
+		t.Fatal(err)
+	}
+
+	d := map[string]string{}
+	explainEnablement(root, systemUnitPaths, "api.service", d)
+	if d["enabled"] != "enabled at runtime" || d["wanted_by"] != "multi-user.target (requires)" {
+		t.Fatalf("unexpected details: %v", d)
+	}
+}
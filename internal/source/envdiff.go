@@ -0,0 +1,132 @@
+package source
+
+import (
+	"strings"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+// unitEnvVar is a variable declared by a systemd unit
+type unitEnvVar struct {
+	Key       string
+	Value     string
+	DefinedIn string
+}
+
+// ExplainEnv compares a process environment with its parent's and, for systemd
+// services, with the unit's Environment= and EnvironmentFile= settings.
+func ExplainEnv(p model.Process, parent model.Process) *model.EnvDiff {
+	diff := &model.EnvDiff{
+		ParentPID:     parent.PID,
+		ParentCommand: parent.Command,
+	}
+	unit, unitVars := readUnitEnvironment(p.PID)
+	diff.Unit = unit
+	diff.Vars = diffEnv(p.Env, parent.Env, unitVars)
+	return diff
+}
+
+func diffEnv(env, parentEnv []string, unitVars []unitEnvVar) []model.EnvVar {
+	parent := envMap(parentEnv)
+
+	// Later declarations win, matching systemd's own precedence
+	unit := make(map[string]unitEnvVar, len(unitVars))
+	for _, u := range unitVars {
+		unit[u.Key] = u
+	}
+
+	vars := make([]model.EnvVar, 0, len(env))
+	for _, entry := range env {
+		key, value, ok := strings.Cut(entry, "=")
+		if !ok {
+			continue
+		}
+		v := model.EnvVar{Key: key, Value: value}
+		parentValue, inParent := parent[key]
+
+		switch u, inUnit := unit[key]; {
+		case inUnit && u.Value == value:
+			v.Origin = "unit-defined"
+			v.DefinedIn = u.DefinedIn
+		case inParent && parentValue == value:
+			v.Origin = "inherited"
+		case inParent:
+			v.Origin = "overridden"
+			v.ParentValue = parentValue
+		default:
+			v.Origin = "added"
+		}
+		vars = append(vars, v)
+	}
+	return vars
+}
+
+func envMap(env []string) map[string]string {
+	m := make(map[string]string, len(env))
+	for _, entry := range env {
+		if key, value, ok := strings.Cut(entry, "="); ok {
+			m[key] = value
+		}
+	}
+	return m
+}
+
+// splitUnitEnvironment splits the value of systemd's Environment= property,
+// which separates assignments with spaces and quotes those containing spaces.
+func splitUnitEnvironment(s string) []string {
+	var out []string
+	var cur strings.Builder
+	var quote rune
+	escaped := false
+	for _, r := range s {
+		switch {
+		case escaped:
+			cur.WriteRune(r)
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				cur.WriteRune(r)
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == ' ' || r == '\t' || r == '\n':
+			if cur.Len() > 0 {
+				out = append(out, cur.String())
+				cur.Reset()
+			}
+		default:
+			cur.WriteRune(r)
+		}
+	}
+	if cur.Len() > 0 {
+		out = append(out, cur.String())
+	}
+	return out
+}
+
+// parseEnvironmentFile reads KEY=VALUE lines as systemd's EnvironmentFile= does,
+// skipping comments and stripping surrounding quotes.
+func parseEnvironmentFile(data string) [][2]string {
+	var out [][2]string
+	for line := range strings.Lines(data) {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		}
+		out = append(out, [2]string{key, value})
+	}
+	return out
+}
@@ -0,0 +1,45 @@
+//go:build linux
+
+package source
+
+import (
+	"os"
+	"strings"
+)
+
+// readUnitEnvironment returns the service unit of pid and the variables it
+// declares through Environment= and EnvironmentFile=.
+func readUnitEnvironment(pid int) (string, []unitEnvVar) {
+	unit := getUnitNameFromCgroup(pid)
+	if !strings.HasSuffix(unit, ".service") {
+		return "", nil
+	}
+
+	m := managerForPID(pid)
+	var vars []unitEnvVar
+	for _, assignment := range splitUnitEnvironment(m.queryProperty("Environment", unit)) {
+		if key, value, ok := strings.Cut(assignment, "="); ok {
+			vars = append(vars, unitEnvVar{Key: key, Value: value, DefinedIn: "Environment="})
+		}
+	}
+
+	for _, path := range m.environmentFiles(unit) {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		for _, kv := range parseEnvironmentFile(string(data)) {
+			vars = append(vars, unitEnvVar{Key: kv[0], Value: kv[1], DefinedIn: path})
+		}
+	}
+
+	return unit, vars
+}
+
+// environmentFiles lists the unit's EnvironmentFile= paths
+func (m systemdManager) environmentFiles(unit string) []string {
+	if v, ok := m.property("EnvironmentFiles", unit); ok {
+		return environmentFilePaths(v)
+	}
+	return environmentFilePaths(m.queryProperty("EnvironmentFiles", unit))
+}
@@ -0,0 +1,7 @@
+//go:build !linux
+
+package source
+
+func readUnitEnvironment(pid int) (string, []unitEnvVar) {
+	return "", nil
+}
@@ -0,0 +1,59 @@
+package source
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestDiffEnv(t *testing.T) {
+	env := []string{"PATH=/usr/bin", "HOME=/srv", "PORT=8080", "DB_URL=postgres://db", "LANG=C"}
+	parent := []string{"PATH=/usr/bin", "HOME=/root", "LANG=C"}
+	unit := []unitEnvVar{
+		{Key: "PORT", Value: "80", DefinedIn: "Environment="},
+		{Key: "PORT", Value: "8080", DefinedIn: "/etc/default/api"},
+		{Key: "DB_URL", Value: "postgres://other", DefinedIn: "Environment="},
+	}
+
+	vars := diffEnv(env, parent, unit)
+	got := make(map[string]string, len(vars))
+	for _, v := range vars {
+		got[v.Key] = v.Origin
+	}
+
+	want := map[string]string{
+		"PATH":   "inherited",
+		"HOME":   "overridden",
+		"PORT":   "unit-defined",
+		"DB_URL": "added",
+		"LANG":   "inherited",
+	}
+	for k, origin := range want {
+		if got[k] != origin {
+			t.Errorf("%s: origin %q, want %q", k, got[k], origin)
+		}
+	}
+
+	if vars[1].ParentValue != "/root" {
+		t.Errorf("expected parent value for HOME, got %q", vars[1].ParentValue)
+	}
+	if vars[2].DefinedIn != "/etc/default/api" {
+		t.Errorf("expected EnvironmentFile to win for PORT, got %q", vars[2].DefinedIn)
+	}
+}
+
+func TestSplitUnitEnvironment(t *testing.T) {
+	got := splitUnitEnvironment(`A=1 "B=two words" C=x\ y`)
+	want := []string{"A=1", "B=two words", "C=x y"}
+	if !slices.Equal(got, want) {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestParseEnvironmentFile(t *testing.T) {
+	data := "# comment\nA=1\n\nB=\"quoted value\"\n; also comment\nnot an assignment\n C = spaced \n"
+	got := parseEnvironmentFile(data)
+	want := [][2]string{{"A", "1"}, {"B", "quoted value"}, {"C", "spaced"}}
+	if !slices.Equal(got, want) {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,260 @@
+package source
+
+import (
+	"bufio"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"hash"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+// rpmFileDigests lists a package's files with their digests; the first line is
+// the digest algorithm number. Replaced in tests.
+var rpmFileDigests = func(root, pkg string) ([]byte, error) {
+	if _, err := exec.LookPath("rpm"); err != nil {
+		return nil, err
+	}
+	return exec.Command("rpm", "--root", root, "-q", "--queryformat",
+		"%{FILEDIGESTALGO}\n[%{FILENAMES}\t%{FILEDIGESTS}\n]", pkg).Output()
+}
+
+// digestAlgos maps the digest names used below to hash constructors
+var digestAlgos = map[string]func() hash.Hash{
+	"md5":    md5.New,
+	"sha1":   sha1.New,
+	"sha256": sha256.New,
+	"sha384": sha512.New384,
+	"sha512": sha512.New,
+}
+
+// rpmDigestAlgos maps rpm's PGPHASHALGO numbers to digest names
+var rpmDigestAlgos = map[string]string{
+	"1":  "md5",
+	"2":  "sha1",
+	"8":  "sha256",
+	"9":  "sha384",
+	"10": "sha512",
+}
+
+// digestCacheSize bounds how many executables keep their digests, enough for
+// the TUI to move between processes without rehashing their binaries
+const digestCacheSize = 64
+
+// digestEntry holds the digests computed for one version of a file
+type digestEntry struct {
+	file os.FileInfo
+	sums map[string]string
+}
+
+var (
+	digestMu    sync.Mutex
+	digestCache []*digestEntry
+)
+
+// VerifyPackage hashes the running executable and compares it with the file
+// on disk and with the checksum recorded by the package manager.
+func VerifyPackage(p model.Process, info *model.PackageInfo) {
+	if info == nil || info.Manual || p.Exe == "" {
+		return
+	}
+	root, _ := processRoot(p.PID)
+	verifyPackage(info, root, runningExePath(p.PID, p.Exe), p.Exe)
+}
+
+func verifyPackage(info *model.PackageInfo, root, running, exe string) {
+	expected, algo := packageDigest(info, root, exe)
+
+	// The on-disk file only needs hashing when it is a different inode
+	onDisk := filepath.Join(root, exe)
+	replaceable := !sameFile(running, onDisk)
+
+	var algos []string
+	if algo != "" {
+		algos = append(algos, algo)
+	}
+	if replaceable {
+		algos = append(algos, "sha256")
+	}
+	if len(algos) == 0 {
+		return
+	}
+	sums, err := hashFile(running, algos...)
+	if err != nil {
+		return
+	}
+
+	if replaceable {
+		diskSums, err := hashFile(onDisk, "sha256")
+		if err != nil || diskSums[0] != sums[len(sums)-1] {
+			info.Integrity = model.IntegrityReplaced
+			return
+		}
+	}
+
+	switch {
+	case expected == "":
+	case strings.EqualFold(expected, sums[0]):
+		info.Integrity = model.IntegrityMatches
+	default:
+		info.Integrity = model.IntegrityModified
+	}
+}
+
+// packageDigest returns the recorded checksum of exe and the name of the hash
+// that produced it
+func packageDigest(info *model.PackageInfo, root, exe string) (string, string) {
+	candidates := usrMergeAliases(exe)
+	switch info.Manager {
+	case "dpkg":
+		if sum := dpkgMD5(root, info.Name, candidates); sum != "" {
+			return sum, "md5"
+		}
+	case "rpm":
+		out, err := rpmFileDigests(root, info.Name)
+		if err != nil {
+			return "", ""
+		}
+		num, files, _ := strings.Cut(string(out), "\n")
+		algo, ok := rpmDigestAlgos[strings.TrimSpace(num)]
+		if !ok {
+			return "", ""
+		}
+		for line := range strings.Lines(files) {
+			name, digest, ok := strings.Cut(strings.TrimSpace(line), "\t")
+			if ok && digest != "" && slices.Contains(candidates, name) {
+				return digest, algo
+			}
+		}
+	}
+	return "", ""
+}
+
+// dpkgMD5 reads /var/lib/dpkg/info/<pkg>[:arch].md5sums, whose lines are
+// "<md5>  <path without leading slash>".
+func dpkgMD5(root, pkg string, candidates []string) string {
+	infoDir := filepath.Join(root, "var", "lib", "dpkg", "info")
+	files, _ := filepath.Glob(filepath.Join(infoDir, pkg+":*.md5sums"))
+	files = append([]string{filepath.Join(infoDir, pkg+".md5sums")}, files...)
+
+	for _, file := range files {
+		f, err := os.Open(file)
+		if err != nil {
+			continue
+		}
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			sum, name, ok := strings.Cut(scanner.Text(), "  ")
+			if ok && slices.Contains(candidates, "/"+strings.TrimPrefix(name, "/")) {
+				f.Close()
+				return sum
+			}
+		}
+		f.Close()
+	}
+	return ""
+}
+
+// hashFile returns the named digests of a file, reusing those computed for
+// the same inode, size and modification time by an earlier analysis.
+func hashFile(path string, algos ...string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	fi, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	digestMu.Lock()
+	entry := cachedDigests(fi)
+	var missing []string
+	for _, algo := range algos {
+		if _, ok := entry.sums[algo]; !ok {
+			missing = append(missing, algo)
+		}
+	}
+	digestMu.Unlock()
+
+	if len(missing) > 0 {
+		hs := make([]hash.Hash, len(missing))
+		writers := make([]io.Writer, len(missing))
+		for i, algo := range missing {
+			hs[i] = digestAlgos[algo]()
+			writers[i] = hs[i]
+		}
+		if _, err := io.Copy(io.MultiWriter(writers...), f); err != nil {
+			return nil, err
+		}
+		digestMu.Lock()
+		for i, algo := range missing {
+			entry.sums[algo] = hex.EncodeToString(hs[i].Sum(nil))
+		}
+		digestMu.Unlock()
+	}
+
+	digestMu.Lock()
+	defer digestMu.Unlock()
+	sums := make([]string, len(algos))
+	for i, algo := range algos {
+		sums[i] = entry.sums[algo]
+	}
+	return sums, nil
+}
+
+// cachedDigests finds or adds the cache entry for fi, dropping the oldest
+// entry once the cache is full. digestMu must be held.
+func cachedDigests(fi os.FileInfo) *digestEntry {
+	for _, e := range digestCache {
+		if os.SameFile(e.file, fi) && e.file.Size() == fi.Size() && e.file.ModTime().Equal(fi.ModTime()) {
+			return e
+		}
+	}
+	e := &digestEntry{file: fi, sums: make(map[string]string)}
+	if len(digestCache) >= digestCacheSize {
+		digestCache = digestCache[1:]
+	}
+	digestCache = append(digestCache, e)
+	return e
+}
+
+// sameFile reports whether both paths resolve to the same inode, in which case
+// there is nothing to compare.
+func sameFile(a, b string) bool {
+	fa, err := os.Stat(a)
+	if err != nil {
+		return false
+	}
+	fb, err := os.Stat(b)
+	if err != nil {
+		return false
+	}
+	return os.SameFile(fa, fb)
+}
+
+// IntegrityWarnings flags executables that no longer match their package or
+// were replaced on disk while running.
+func IntegrityWarnings(info *model.PackageInfo) []string {
+	if info == nil {
+		return nil
+	}
+	switch info.Integrity {
+	case model.IntegrityModified:
+		return []string{"Executable does not match the checksum recorded by " + info.Manager + " for " + info.Name + " (modified)"}
+	case model.IntegrityReplaced:
+		return []string{"Executable was replaced on disk since the process started (restart to run the new binary)"}
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package source
+
+import (
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+func md5Hex(s string) string {
+	sum := md5.Sum([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestVerifyPackageDpkg(t *testing.T) {
+	const binary = "\x7fELF nginx build"
+
+	tests := []struct {
+		name     string
+		recorded string // content the md5sums entry was computed from
+		disk     string // content currently at the exe path
+		running  string // content of the mapped executable, "" when it is the on-disk file
+		want     string
+	}{
+		{"matches", binary, binary, "", model.IntegrityMatches},
+		{"modified in place", "\x7fELF original", binary, "", model.IntegrityModified},
+		{"replaced by upgrade", binary, "\x7fELF newer build", binary, model.IntegrityReplaced},
+		{"reinstalled identical copy", binary, binary, binary, model.IntegrityMatches},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			writeFixture(t, root, "var/lib/dpkg/info/nginx-core:amd64.md5sums",
+				md5Hex("other")+"  usr/share/doc/nginx-core/copyright\n"+md5Hex(tt.recorded)+"  usr/sbin/nginx\n")
+			writeFixture(t, root, "usr/sbin/nginx", tt.disk)
+
+			running := filepath.Join(root, "usr/sbin/nginx")
+			if tt.running != "" {
+				running = filepath.Join(t.TempDir(), "exe")
+				if err := os.WriteFile(running, []byte(tt.running), 0o755); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			info := &model.PackageInfo{Name: "nginx-core", Version: "1.24.0-2", Manager: "dpkg"}
+			verifyPackage(info, root, running, "/usr/sbin/nginx")
+			if info.Integrity != tt.want {
+				t.Fatalf("Integrity = %q, want %q", info.Integrity, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerifyPackageRpm(t *testing.T) {
+	const binary = "\x7fELF sshd"
+	sum := sha256.Sum256([]byte(binary))
+
+	root := t.TempDir()
+	writeFixture(t, root, "usr/sbin/sshd", binary)
+
+	orig := rpmFileDigests
+	t.Cleanup(func() { rpmFileDigests = orig })
+	rpmFileDigests = func(r, pkg string) ([]byte, error) {
+		if pkg != "openssh-server" {
+			t.Fatalf("unexpected package %q", pkg)
+		}
+		return []byte("8\n/etc/ssh/sshd_config\t\n/usr/sbin/sshd\t" + hex.EncodeToString(sum[:]) + "\n"), nil
+	}
+
+	info := &model.PackageInfo{Name: "openssh-server", Manager: "rpm"}
+	verifyPackage(info, root, filepath.Join(root, "usr/sbin/sshd"), "/usr/sbin/sshd")
+	if info.Integrity != model.IntegrityMatches {
+		t.Fatalf("Integrity = %q, want %q", info.Integrity, model.IntegrityMatches)
+	}
+}
+
+func TestVerifyPackageWithoutDigest(t *testing.T) {
+	root := t.TempDir()
+	writeFixture(t, root, "usr/bin/nginx", "binary")
+
+	info := &model.PackageInfo{Name: "nginx", Manager: "pacman"}
+	verifyPackage(info, root, filepath.Join(root, "usr/bin/nginx"), "/usr/bin/nginx")
+	if info.Integrity != "" {
+		t.Fatalf("expected no verdict without a recorded digest, got %q", info.Integrity)
+	}
+}
+
+func TestHashFileCachesDigests(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "exe")
+	if err := os.WriteFile(path, []byte("\x7fELF one"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	fi, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	first, err := hashFile(path, "md5")
+	if err != nil || first[0] != md5Hex("\x7fELF one") {
+		t.Fatalf("hashFile() = %v, %v", first, err)
+	}
+
+	// Same inode, size and mtime: served from the cache without reading
+	if err := os.WriteFile(path, []byte("\x7fELF two"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(path, fi.ModTime(), fi.ModTime()); err != nil {
+		t.Fatal(err)
+	}
+	if sums, _ := hashFile(path, "md5"); sums[0] != first[0] {
+		t.Errorf("expected cached digest %s, got %s", first[0], sums[0])
+	}
+
+	// A new mtime invalidates the entry
+	if err := os.Chtimes(path, fi.ModTime(), fi.ModTime().Add(time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	if sums, _ := hashFile(path, "md5"); sums[0] != md5Hex("\x7fELF two") {
+		t.Errorf("expected digest of the rewritten file, got %s", sums[0])
+	}
+}
+
+func TestIntegrityWarnings(t *testing.T) {
+	if w := IntegrityWarnings(&model.PackageInfo{Integrity: model.IntegrityMatches}); len(w) != 0 {
+		t.Fatalf("expected no warning for matching package, got %v", w)
+	}
+	w := IntegrityWarnings(&model.PackageInfo{Name: "nginx-core", Manager: "dpkg", Integrity: model.IntegrityModified})
+	want := "Executable does not match the checksum recorded by dpkg for nginx-core (modified)"
+	if !slices.Contains(w, want) {
+		t.Fatalf("expected %q, got %v", want, w)
+	}
+	if w := IntegrityWarnings(&model.PackageInfo{Integrity: model.IntegrityReplaced}); len(w) != 1 {
+		t.Fatalf("expected replaced warning, got %v", w)
+	}
+}
@@ -0,0 +1,163 @@
+package source
+
+import (
+	"strings"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+// kernelThreadFamily explains a family of kernel threads identified by name prefix
+type kernelThreadFamily struct {
+	prefix  string
+	name    string
+	explain func(suffix string) string
+}
+
+// kernelThreadCatalogue is matched in order, so longer prefixes come first
+var kernelThreadCatalogue = []kernelThreadFamily{
+	{"kthreadd", "kthreadd", func(string) string {
+		return "Kernel thread daemon; parent of every other kernel thread"
+	}},
+	{"kworker/", "kworker", explainKworker},
+	{"ksoftirqd/", "ksoftirqd", func(s string) string {
+		return "Handles softirqs (deferred network and block I/O interrupt work) that overflow on CPU " + s
+	}},
+	{"kswapd", "kswapd", func(s string) string {
+		return "Reclaims memory by swapping and evicting pages for NUMA node " + s + " when free memory runs low"
+	}},
+	{"kcompactd", "kcompactd", func(s string) string {
+		return "Compacts memory to create contiguous free pages on NUMA node " + s
+	}},
+	{"jbd2/", "jbd2", func(s string) string {
+		dev, _, _ := strings.Cut(s, "-")
+		return "ext4/ext3 journal commit thread for " + dev
+	}},
+	{"nfsd", "nfsd", func(string) string {
+		return "Kernel NFS server thread serving client requests"
+	}},
+	{"lockd", "lockd", func(string) string {
+		return "NFS lock manager thread"
+	}},
+	{"wg-crypt-", "wireguard", func(s string) string {
+		return "WireGuard encryption worker for interface " + s
+	}},
+	{"wireguard", "wireguard", func(string) string {
+		return "WireGuard kernel worker"
+	}},
+	{"txg_", "zfs", explainZFS},
+	{"z_", "zfs", explainZFS},
+	{"spa_", "zfs", explainZFS},
+	{"arc_", "zfs", explainZFS},
+	{"dbu_", "zfs", explainZFS},
+	{"dp_", "zfs", explainZFS},
+	{"migration/", "migration", func(s string) string {
+		return "Stopper thread that migrates tasks off CPU " + s + " (load balancing, hotplug)"
+	}},
+	{"cpuhp/", "cpuhp", func(s string) string {
+		return "CPU hotplug state machine for CPU " + s
+	}},
+	{"rcu_", "rcu", func(string) string {
+		return "Read-copy-update grace period processing"
+	}},
+	{"irq/", "irq", func(s string) string {
+		num, dev, _ := strings.Cut(s, "-")
+		if dev != "" {
+			return "Threaded interrupt handler for IRQ " + num + " (" + dev + ")"
+		}
+		return "Threaded interrupt handler for IRQ " + num
+	}},
+	{"watchdog", "watchdog", func(string) string {
+		return "Lockup detector watchdog"
+	}},
+	{"khugepaged", "khugepaged", func(string) string {
+		return "Collapses small pages into transparent huge pages"
+	}},
+	{"kauditd", "kauditd", func(string) string {
+		return "Delivers audit records to auditd"
+	}},
+	{"oom_reaper", "oom_reaper", func(string) string {
+		return "Reclaims memory from processes killed by the OOM killer"
+	}},
+	{"kblockd", "kblockd", func(string) string {
+		return "Block layer request dispatch"
+	}},
+}
+
+// detectKernel explains kernel threads, which are spawned by kthreadd rather than
+// any user-space supervisor.
+func detectKernel(ancestry []model.Process) *model.Source {
+	if len(ancestry) == 0 {
+		return nil
+	}
+	target := ancestry[len(ancestry)-1]
+	if !target.KernelThread {
+		return nil
+	}
+
+	src := &model.Source{
+		Type:        model.SourceKernel,
+		Name:        "kernel thread",
+		Description: "Kernel thread created by kthreadd; not started by any user-space process",
+		Details: map[string]string{
+			"thread": target.Command,
+		},
+	}
+
+	for _, fam := range kernelThreadCatalogue {
+		suffix, ok := strings.CutPrefix(target.Command, fam.prefix)
+		if !ok {
+			continue
+		}
+		src.Name = fam.name
+		src.Description = fam.explain(suffix)
+		if fam.name == "kworker" {
+			if wq := kworkerWorkqueue(suffix); wq != "" {
+				src.Details["workqueue"] = wq
+			}
+		}
+		break
+	}
+
+	return src
+}
+
+// kworkerWorkqueue extracts the workqueue from names such as "kworker/3:1-events",
+// "kworker/u16:2+flush-8:0" or "kworker/R-kblockd" ('+' means currently running).
+func kworkerWorkqueue(suffix string) string {
+	if i := strings.IndexAny(suffix, "-+"); i != -1 {
+		return suffix[i+1:]
+	}
+	return ""
+}
+
+func explainKworker(suffix string) string {
+	pool, _, _ := strings.Cut(suffix, "-")
+	pool, _, _ = strings.Cut(pool, "+")
+
+	var desc string
+	switch {
+	case strings.HasPrefix(pool, "R"):
+		desc = "Rescuer worker that guarantees forward progress for a workqueue under memory pressure"
+	case strings.HasPrefix(pool, "u"):
+		desc = "Kernel worker executing deferred work from an unbound (any CPU) workqueue pool"
+	default:
+		cpu, _, _ := strings.Cut(pool, ":")
+		desc = "Kernel worker executing deferred work on CPU " + cpu
+		if strings.HasSuffix(pool, "H") {
+			desc += " (high priority)"
+		}
+	}
+
+	if i := strings.IndexAny(suffix, "-+"); i != -1 {
+		state := "last ran"
+		if suffix[i] == '+' {
+			state = "currently running"
+		}
+		desc += "; " + state + " workqueue " + suffix[i+1:]
+	}
+	return desc
+}
+
+func explainZFS(string) string {
+	return "ZFS I/O pipeline or pool maintenance thread (OpenZFS kernel module)"
+}
@@ -0,0 +1,55 @@
+package source
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+func TestDetectKernel(t *testing.T) {
+	tests := []struct {
+		comm      string
+		name      string
+		contains  string
+		workqueue string
+	}{
+		{"kworker/3:1-events", "kworker", "on CPU 3; last ran workqueue events", "events"},
+		{"kworker/u16:2+flush-8:0", "kworker", "unbound", "flush-8:0"},
+		{"kworker/0:0H-kblockd", "kworker", "(high priority)", "kblockd"},
+		{"kworker/R-mm_percpu_wq", "kworker", "Rescuer", "mm_percpu_wq"},
+		{"ksoftirqd/7", "ksoftirqd", "CPU 7", ""},
+		{"kswapd0", "kswapd", "NUMA node 0", ""},
+		{"jbd2/nvme0n1p2-8", "jbd2", "for nvme0n1p2", ""},
+		{"nfsd", "nfsd", "NFS server", ""},
+		{"wg-crypt-wg0", "wireguard", "interface wg0", ""},
+		{"txg_sync", "zfs", "ZFS", ""},
+		{"irq/42-nvme0q1", "irq", "IRQ 42 (nvme0q1)", ""},
+		{"some_driver_thread", "kernel thread", "kthreadd", ""},
+	}
+
+	for _, tt := range tests {
+		ancestry := []model.Process{
+			{PID: 2, Command: "kthreadd", KernelThread: true},
+			{PID: 100, PPID: 2, Command: tt.comm, KernelThread: true},
+		}
+		src := Detect(ancestry)
+		if src.Type != model.SourceKernel || src.Name != tt.name {
+			t.Errorf("%s: got type %q name %q", tt.comm, src.Type, src.Name)
+			continue
+		}
+		if !strings.Contains(src.Description, tt.contains) {
+			t.Errorf("%s: description %q missing %q", tt.comm, src.Description, tt.contains)
+		}
+		if src.Details["workqueue"] != tt.workqueue {
+			t.Errorf("%s: workqueue %q, want %q", tt.comm, src.Details["workqueue"], tt.workqueue)
+		}
+	}
+}
+
+func TestDetectKernelIgnoresUserProcesses(t *testing.T) {
+	ancestry := []model.Process{{PID: 1, Command: "systemd"}, {PID: 50, PPID: 1, Command: "kworker-lookalike"}}
+	if src := detectKernel(ancestry); src != nil {
+		t.Fatalf("expected nil, got %+v", src)
+	}
+}
@@ -0,0 +1,187 @@
+package source
+
+import (
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+// multiplexerSession identifies the session and window a process lives in
+type multiplexerSession struct {
+	Name   string // multiplexer: tmux, screen, zellij, abduco
+	Server int    // server pid
+	Socket string
+	Window string
+	Pane   string
+	Title  string // window name
+}
+
+// tmuxListPanes runs `tmux list-panes -a` against a server socket; replaced in tests
+var tmuxListPanes = func(socket string) ([]byte, error) {
+	if _, err := exec.LookPath("tmux"); err != nil {
+		return nil, err
+	}
+	args := []string{}
+	if socket != "" {
+		args = append(args, "-S", socket)
+	}
+	args = append(args, "list-panes", "-a", "-F", "#{pane_id}\t#{pane_pid}\t#{session_name}\t#{window_index}\t#{window_name}")
+	return exec.Command("tmux", args...).Output()
+}
+
+// multiplexerName returns the multiplexer a server process belongs to
+func multiplexerName(p model.Process) string {
+	cmd := strings.ToLower(p.Command)
+	switch {
+	case cmd == "tmux" || strings.HasPrefix(cmd, "tmux: server"):
+		return "tmux"
+	case cmd == "screen":
+		return "screen"
+	case cmd == "zellij":
+		return "zellij"
+	case cmd == "abduco":
+		return "abduco"
+	}
+	return ""
+}
+
+func detectMultiplexer(ancestry []model.Process) *model.Source {
+	idx := -1
+	for i := len(ancestry) - 2; i >= 0; i-- {
+		if multiplexerName(ancestry[i]) != "" {
+			idx = i
+			break
+		}
+	}
+	if idx == -1 {
+		return nil
+	}
+
+	s := &multiplexerSession{
+		Name:   multiplexerName(ancestry[idx]),
+		Server: ancestry[idx].PID,
+	}
+	below := ancestry[idx+1:]
+
+	switch s.Name {
+	case "tmux":
+		resolveTmux(s, ancestry[idx], below)
+	case "screen":
+		// STY is "<pid>.<name>", WINDOW the window number
+		if sty := envLookup(below, "STY"); sty != "" {
+			if _, name, ok := strings.Cut(sty, "."); ok {
+				s.Title = name
+			}
+		}
+		s.Window = envLookup(below, "WINDOW")
+	case "zellij":
+		s.Title = envLookup(below, "ZELLIJ_SESSION_NAME")
+		s.Pane = envLookup(below, "ZELLIJ_PANE_ID")
+	case "abduco":
+		s.Title = envLookup(below, "ABDUCO_SESSION")
+		if s.Title == "" {
+			s.Title = abducoSessionFromCmdline(ancestry[idx].Cmdline)
+		}
+	}
+
+	src := &model.Source{
+		Type:        model.SourceMultiplexer,
+		Name:        s.Name,
+		Description: s.describe(),
+		Details: map[string]string{
+			"server_pid": strconv.Itoa(s.Server),
+		},
+	}
+	if s.Title != "" {
+		src.Details["session"] = s.Title
+	}
+	if s.Window != "" {
+		src.Details["window"] = s.Window
+	}
+	if s.Pane != "" {
+		src.Details["pane"] = s.Pane
+	}
+	if s.Socket != "" {
+		src.Details["socket"] = s.Socket
+	}
+	return src
+}
+
+// resolveTmux reads the TMUX/TMUX_PANE variables and asks the server which
+// session and window own the pane, matching by pane id or pane pid. The
+// socket path comes from the target's environment, so it is only used when
+// the socket belongs to the user running the tmux server.
+func resolveTmux(s *multiplexerSession, server model.Process, below []model.Process) {
+	// TMUX is "<socket>,<server pid>,<session index>"
+	if v := envLookup(below, "TMUX"); v != "" {
+		s.Socket, _, _ = strings.Cut(v, ",")
+	}
+	s.Pane = envLookup(below, "TMUX_PANE")
+
+	if s.Socket != "" {
+		if owner, ok := socketOwner(s.Socket); !ok || owner != server.User {
+			return
+		}
+	}
+
+	out, err := tmuxListPanes(s.Socket)
+	if err != nil {
+		return
+	}
+
+	pids := make(map[string]bool, len(below))
+	for _, p := range below {
+		pids[strconv.Itoa(p.PID)] = true
+	}
+
+	for _, line := range strings.Split(string(out), "\n") {
+		fields := strings.SplitN(line, "\t", 5)
+		if len(fields) < 5 {
+			continue
+		}
+		if (s.Pane != "" && fields[0] == s.Pane) || pids[fields[1]] {
+			s.Pane = fields[0]
+			s.Title = fields[2]
+			s.Window = fields[3]
+			return
+		}
+	}
+}
+
+func (s *multiplexerSession) describe() string {
+	desc := s.Name + " session"
+	if s.Title != "" {
+		desc += fmt.Sprintf(" '%s'", s.Title)
+	}
+	if s.Window != "" {
+		desc += ", window " + s.Window
+	}
+	return desc
+}
+
+// envLookup returns the value of key from the closest process to the target
+func envLookup(procs []model.Process, key string) string {
+	prefix := key + "="
+	for i := len(procs) - 1; i >= 0; i-- {
+		for _, e := range procs[i].Env {
+			if value, ok := strings.CutPrefix(e, prefix); ok {
+				return value
+			}
+		}
+	}
+	return ""
+}
+
+// abducoSessionFromCmdline extracts the session from "abduco -c <name> cmd"
+func abducoSessionFromCmdline(cmdline string) string {
+	fields := strings.Fields(cmdline)
+	for i := 1; i < len(fields)-1; i++ {
+		if strings.HasPrefix(fields[i], "-") && strings.ContainsAny(fields[i], "cnAa") {
+			return fields[i+1]
+		}
+	}
+	return ""
+}
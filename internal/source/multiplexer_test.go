@@ -0,0 +1,127 @@
+package source
+
+import (
+	"testing"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+// stubSocketOwner makes every socket appear owned by user
+func stubSocketOwner(t *testing.T, user string) {
+	orig := socketOwner
+	t.Cleanup(func() { socketOwner = orig })
+	socketOwner = func(string) (string, bool) { return user, true }
+}
+
+func TestDetectMultiplexerTmux(t *testing.T) {
+	orig := tmuxListPanes
+	defer func() { tmuxListPanes = orig }()
+	stubSocketOwner(t, "alice")
+
+	var gotSocket string
+	tmuxListPanes = func(socket string) ([]byte, error) {
+		gotSocket = socket
+		return []byte("%0\t3000\tmain\t0\tzsh\n%5\t3100\tapi\t2\tserver\n"), nil
+	}
+
+	ancestry := []model.Process{
+		{PID: 1, Command: "systemd"},
+		{PID: 2900, Command: "tmux: server", Cmdline: "tmux new -s api", User: "alice"},
+		{PID: 3100, Command: "bash", Env: []string{"TMUX=/tmp/tmux-1000/default,2900,1", "TMUX_PANE=%5"}},
+		{PID: 3200, Command: "node"},
+	}
+
+	src := Detect(ancestry)
+	if src.Type != model.SourceMultiplexer || src.Name != "tmux" {
+		t.Fatalf("expected tmux multiplexer, got %+v", src)
+	}
+	if src.Description != "tmux session 'api', window 2" {
+		t.Fatalf("unexpected description: %q", src.Description)
+	}
+	if gotSocket != "/tmp/tmux-1000/default" {
+		t.Fatalf("unexpected socket: %q", gotSocket)
+	}
+	if src.Details["pane"] != "%5" || src.Details["server_pid"] != "2900" {
+		t.Fatalf("unexpected details: %v", src.Details)
+	}
+}
+
+func TestDetectMultiplexerTmuxForeignSocket(t *testing.T) {
+	orig := tmuxListPanes
+	defer func() { tmuxListPanes = orig }()
+	stubSocketOwner(t, "mallory")
+	tmuxListPanes = func(string) ([]byte, error) {
+		t.Fatal("tmux queried through a socket the server's user does not own")
+		return nil, nil
+	}
+
+	ancestry := []model.Process{
+		{PID: 2900, Command: "tmux: server", User: "alice"},
+		{PID: 3100, Command: "bash", Env: []string{"TMUX=/tmp/evil,2900,1", "TMUX_PANE=%5"}},
+		{PID: 3200, Command: "node"},
+	}
+	src := detectMultiplexer(ancestry)
+	if src == nil || src.Details["pane"] != "%5" || src.Details["session"] != "" {
+		t.Fatalf("unexpected source: %+v", src)
+	}
+}
+
+func TestDetectMultiplexerTmuxByPanePID(t *testing.T) {
+	orig := tmuxListPanes
+	defer func() { tmuxListPanes = orig }()
+	tmuxListPanes = func(string) ([]byte, error) {
+		return []byte("%1\t4100\tdev\t3\tlogs\n"), nil
+	}
+
+	// Environment not readable: fall back to matching the pane's shell pid
+	ancestry := []model.Process{
+		{PID: 4000, Command: "tmux: server"},
+		{PID: 4100, Command: "bash"},
+		{PID: 4200, Command: "tail"},
+	}
+	src := detectMultiplexer(ancestry)
+	if src == nil || src.Description != "tmux session 'dev', window 3" {
+		t.Fatalf("unexpected source: %+v", src)
+	}
+}
+
+func TestDetectMultiplexerScreen(t *testing.T) {
+	ancestry := []model.Process{
+		{PID: 500, Command: "screen", Cmdline: "SCREEN -S build"},
+		{PID: 501, Command: "bash", Env: []string{"STY=500.build", "WINDOW=1"}},
+		{PID: 502, Command: "make"},
+	}
+	src := detectMultiplexer(ancestry)
+	if src == nil || src.Description != "screen session 'build', window 1" {
+		t.Fatalf("unexpected source: %+v", src)
+	}
+}
+
+func TestDetectMultiplexerZellijAndAbduco(t *testing.T) {
+	zellij := []model.Process{
+		{PID: 600, Command: "zellij"},
+		{PID: 601, Command: "fish", Env: []string{"ZELLIJ_SESSION_NAME=work", "ZELLIJ_PANE_ID=2"}},
+	}
+	if src := detectMultiplexer(zellij); src == nil || src.Description != "zellij session 'work'" {
+		t.Fatalf("unexpected zellij source: %+v", src)
+	}
+
+	abduco := []model.Process{
+		{PID: 700, Command: "abduco", Cmdline: "abduco -c irc weechat"},
+		{PID: 701, Command: "weechat"},
+	}
+	if src := detectMultiplexer(abduco); src == nil || src.Description != "abduco session 'irc'" {
+		t.Fatalf("unexpected abduco source: %+v", src)
+	}
+}
+
+func TestDetectMultiplexerIgnoresTarget(t *testing.T) {
+	// The multiplexer server itself is not "started by" a multiplexer
+	ancestry := []model.Process{
+		{PID: 1, Command: "systemd"},
+		{PID: 2900, Command: "tmux: server"},
+	}
+	if src := detectMultiplexer(ancestry); src != nil {
+		t.Fatalf("expected nil, got %+v", src)
+	}
+}
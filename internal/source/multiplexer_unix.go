@@ -0,0 +1,28 @@
+//go:build linux || darwin || freebsd
+
+package source
+
+import (
+	"os"
+	"os/user"
+	"strconv"
+	"syscall"
+)
+
+// socketOwner returns the user owning a unix socket, without following
+// symlinks; replaced in tests
+var socketOwner = func(path string) (string, bool) {
+	info, err := os.Lstat(path)
+	if err != nil || info.Mode()&os.ModeSocket == 0 {
+		return "", false
+	}
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return "", false
+	}
+	uid := strconv.FormatUint(uint64(stat.Uid), 10)
+	if u, err := user.LookupId(uid); err == nil {
+		return u.Username, true
+	}
+	return uid, true
+}
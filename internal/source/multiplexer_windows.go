@@ -0,0 +1,8 @@
+//go:build windows
+
+package source
+
+// socketOwner is unsupported on Windows, where tmux does not run natively
+var socketOwner = func(string) (string, bool) {
+	return "", false
+}
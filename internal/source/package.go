@@ -0,0 +1,408 @@
+package source
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+// manualPrefixes are conventional locations for software installed by hand
+var manualPrefixes = []string{"/usr/local", "/opt"}
+
+// rpmQueryFile asks rpm which package owns path inside root. The rpm database
+// is Berkeley DB or SQLite depending on the distribution, so it is read through
+// rpm itself; replaced in tests.
+var rpmQueryFile = func(root, file string) ([]byte, error) {
+	if _, err := exec.LookPath("rpm"); err != nil {
+		return nil, err
+	}
+	return exec.Command("rpm", "--root", root, "-qf", "--queryformat", "%{NAME}\t%{VERSION}-%{RELEASE}\n", file).Output()
+}
+
+// ResolvePackage reports which package manager installed the executable of p.
+// Databases are read through the process's own root so containerised
+// processes are matched against the container's packages, not the host's.
+func ResolvePackage(p model.Process) *model.PackageInfo {
+	if p.Exe == "" || p.KernelThread {
+		return nil
+	}
+	root, ok := processRoot(p.PID)
+	if !ok && p.Container != "" {
+		return nil
+	}
+	return resolvePackage(root, p.Exe)
+}
+
+func resolvePackage(root, exe string) *model.PackageInfo {
+	exe = path.Clean(filepath.ToSlash(exe))
+	if !strings.HasPrefix(exe, "/") {
+		return nil
+	}
+
+	if info := snapPackage(root, exe); info != nil {
+		return info
+	}
+	if info := nixPackage(exe); info != nil {
+		return info
+	}
+
+	candidates := usrMergeAliases(exe)
+	for _, lookup := range []func(string, []string) *model.PackageInfo{
+		dpkgPackage, apkPackage, pacmanPackage, rpmPackage,
+	} {
+		if info := lookup(root, candidates); info != nil {
+			return info
+		}
+	}
+
+	for _, prefix := range manualPrefixes {
+		if strings.HasPrefix(exe, prefix+"/") {
+			return &model.PackageInfo{Manual: true, Prefix: prefix}
+		}
+	}
+	return nil
+}
+
+// usrMergeAliases returns the path plus its /bin ↔ /usr/bin spelling, since
+// merged-/usr systems list files under either depending on package age.
+func usrMergeAliases(exe string) []string {
+	aliases := []string{exe}
+	for _, dir := range []string{"/bin/", "/sbin/", "/lib/", "/lib64/"} {
+		if rest, ok := strings.CutPrefix(exe, dir); ok {
+			return append(aliases, "/usr"+dir+rest)
+		}
+		if rest, ok := strings.CutPrefix(exe, "/usr"+dir); ok {
+			return append(aliases, dir+rest)
+		}
+	}
+	return aliases
+}
+
+// dpkgSearch asks dpkg-query which packages own the candidate paths. It exits
+// 1 when some of them are not owned, which is not an error here. Replaced in
+// tests.
+var dpkgSearch = func(admindir string, candidates []string) ([]byte, error) {
+	if _, err := exec.LookPath("dpkg-query"); err != nil {
+		return nil, err
+	}
+	args := append([]string{"--admindir=" + admindir, "--search"}, candidates...)
+	out, err := exec.Command("dpkg-query", args...).Output()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+		return out, nil
+	}
+	return out, err
+}
+
+// dpkgPackage finds the owner with dpkg-query and takes the version from
+// status. Without dpkg-query, as for a Debian container on another host, it
+// scans /var/lib/dpkg/info/*.list, likeliest packages first.
+func dpkgPackage(root string, candidates []string) *model.PackageInfo {
+	admindir := filepath.Join(root, "var", "lib", "dpkg")
+	if _, err := os.Stat(filepath.Join(admindir, "status")); err != nil {
+		return nil
+	}
+
+	var pkg string
+	if out, err := dpkgSearch(admindir, candidates); err == nil {
+		pkg = parseDpkgSearch(out, candidates)
+	} else {
+		pkg = scanDpkgLists(filepath.Join(admindir, "info"), candidates)
+	}
+	if pkg == "" {
+		return nil
+	}
+	name, arch, _ := strings.Cut(pkg, ":")
+	return &model.PackageInfo{
+		Name:    name,
+		Version: dpkgVersion(root, name, arch),
+		Manager: "dpkg",
+	}
+}
+
+// parseDpkgSearch reads "pkg[:arch][, other]: /path" lines, skipping
+// diversions, and returns the first package owning a candidate
+func parseDpkgSearch(out []byte, candidates []string) string {
+	for line := range strings.Lines(string(out)) {
+		if strings.HasPrefix(line, "diversion ") {
+			continue
+		}
+		pkgs, file, ok := strings.Cut(strings.TrimSpace(line), ": ")
+		if !ok || !slices.Contains(candidates, file) {
+			continue
+		}
+		pkg, _, _ := strings.Cut(pkgs, ", ")
+		return pkg
+	}
+	return ""
+}
+
+// scanDpkgLists reads package file lists until one holds a candidate,
+// starting with packages named like the executable so the whole database is
+// rarely read
+func scanDpkgLists(infoDir string, candidates []string) string {
+	lists, err := filepath.Glob(filepath.Join(infoDir, "*.list"))
+	if err != nil {
+		return ""
+	}
+	exe := path.Base(candidates[0])
+	likely := func(list string) bool {
+		name, _, _ := strings.Cut(strings.TrimSuffix(filepath.Base(list), ".list"), ":")
+		return strings.Contains(name, exe) || strings.Contains(exe, name)
+	}
+	slices.SortStableFunc(lists, func(a, b string) int {
+		switch la, lb := likely(a), likely(b); {
+		case la && !lb:
+			return -1
+		case lb && !la:
+			return 1
+		}
+		return 0
+	})
+
+	for _, list := range lists {
+		data, err := os.ReadFile(list)
+		if err == nil && containsLine(data, candidates) {
+			return strings.TrimSuffix(filepath.Base(list), ".list")
+		}
+	}
+	return ""
+}
+
+func dpkgVersion(root, name, arch string) string {
+	f, err := os.Open(filepath.Join(root, "var", "lib", "dpkg", "status"))
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+
+	var pkg, pkgArch, version string
+	match := func() bool {
+		return pkg == name && (arch == "" || pkgArch == arch || pkgArch == "all")
+	}
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			if match() {
+				return version
+			}
+			pkg, pkgArch, version = "", "", ""
+			continue
+		}
+		key, value, ok := strings.Cut(line, ": ")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "Package":
+			pkg = value
+		case "Architecture":
+			pkgArch = value
+		case "Version":
+			version = value
+		}
+	}
+	if match() {
+		return version
+	}
+	return ""
+}
+
+// apkPackage reads Alpine's /lib/apk/db/installed, where each package lists
+// its directories (F:) and the files within them (R:).
+func apkPackage(root string, candidates []string) *model.PackageInfo {
+	f, err := os.Open(filepath.Join(root, "lib", "apk", "db", "installed"))
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+
+	want := make(map[string]bool, len(candidates))
+	for _, c := range candidates {
+		want[strings.TrimPrefix(c, "/")] = true
+	}
+
+	var name, version, dir string
+	found := false
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			if found {
+				break
+			}
+			name, version, dir = "", "", ""
+			continue
+		}
+		if len(line) < 2 || line[1] != ':' {
+			continue
+		}
+		value := line[2:]
+		switch line[0] {
+		case 'P':
+			name = value
+		case 'V':
+			version = value
+		case 'F':
+			dir = value
+		case 'R':
+			if want[path.Join(dir, value)] {
+				found = true
+			}
+		}
+	}
+	if !found {
+		return nil
+	}
+	return &model.PackageInfo{Name: name, Version: version, Manager: "apk"}
+}
+
+// pacmanPackage searches the %FILES% lists of Arch's local database
+func pacmanPackage(root string, candidates []string) *model.PackageInfo {
+	entries, err := filepath.Glob(filepath.Join(root, "var", "lib", "pacman", "local", "*", "files"))
+	if err != nil || len(entries) == 0 {
+		return nil
+	}
+	relative := make([]string, len(candidates))
+	for i, c := range candidates {
+		relative[i] = strings.TrimPrefix(c, "/")
+	}
+	for _, files := range entries {
+		data, err := os.ReadFile(files)
+		if err != nil || !containsLine(data, relative) {
+			continue
+		}
+		desc, err := os.ReadFile(filepath.Join(filepath.Dir(files), "desc"))
+		if err != nil {
+			continue
+		}
+		fields := pacmanDesc(desc)
+		return &model.PackageInfo{Name: fields["NAME"], Version: fields["VERSION"], Manager: "pacman"}
+	}
+	return nil
+}
+
+// pacmanDesc parses "%KEY%\nvalue\n\n" blocks, keeping the first value line
+func pacmanDesc(data []byte) map[string]string {
+	fields := make(map[string]string)
+	key := ""
+	for line := range strings.Lines(string(data)) {
+		line = strings.TrimRight(line, "\n")
+		switch {
+		case strings.HasPrefix(line, "%") && strings.HasSuffix(line, "%") && len(line) > 2:
+			key = line[1 : len(line)-1]
+		case line == "":
+			key = ""
+		case key != "":
+			if _, ok := fields[key]; !ok {
+				fields[key] = line
+			}
+		}
+	}
+	return fields
+}
+
+func rpmPackage(root string, candidates []string) *model.PackageInfo {
+	hasDB := false
+	for _, dir := range []string{"var/lib/rpm", "usr/lib/sysimage/rpm"} {
+		if fi, err := os.Stat(filepath.Join(root, dir)); err == nil && fi.IsDir() {
+			hasDB = true
+			break
+		}
+	}
+	if !hasDB {
+		return nil
+	}
+	for _, c := range candidates {
+		out, err := rpmQueryFile(root, c)
+		if err != nil {
+			continue
+		}
+		line, _, _ := strings.Cut(strings.TrimSpace(string(out)), "\n")
+		name, version, ok := strings.Cut(line, "\t")
+		if !ok || name == "" {
+			continue
+		}
+		return &model.PackageInfo{Name: name, Version: version, Manager: "rpm"}
+	}
+	return nil
+}
+
+// nixPackage derives name and version from /nix/store/<hash>-<name>-<version>/...
+func nixPackage(exe string) *model.PackageInfo {
+	rest, ok := strings.CutPrefix(exe, "/nix/store/")
+	if !ok {
+		return nil
+	}
+	entry, _, _ := strings.Cut(rest, "/")
+	_, nameVersion, ok := strings.Cut(entry, "-")
+	if !ok || nameVersion == "" {
+		return nil
+	}
+	info := &model.PackageInfo{Name: nameVersion, Manager: "nix"}
+	// The version starts at the first dash followed by a digit
+	for i := 0; i+1 < len(nameVersion); i++ {
+		if nameVersion[i] == '-' && nameVersion[i+1] >= '0' && nameVersion[i+1] <= '9' {
+			info.Name, info.Version = nameVersion[:i], nameVersion[i+1:]
+			break
+		}
+	}
+	return info
+}
+
+// snapPackage handles /snap/<name>/<revision>/..., reading the version from meta/snap.yaml
+func snapPackage(root, exe string) *model.PackageInfo {
+	rest, ok := strings.CutPrefix(exe, "/snap/")
+	if !ok {
+		return nil
+	}
+	parts := strings.SplitN(rest, "/", 3)
+	if len(parts) < 3 || parts[0] == "bin" {
+		return nil
+	}
+	name, revision := parts[0], parts[1]
+	info := &model.PackageInfo{Name: name, Version: "rev " + revision, Manager: "snap"}
+
+	data, err := os.ReadFile(filepath.Join(root, "snap", name, revision, "meta", "snap.yaml"))
+	if err != nil {
+		return info
+	}
+	for line := range strings.Lines(string(data)) {
+		if v, ok := strings.CutPrefix(line, "version:"); ok {
+			if v = strings.Trim(strings.TrimSpace(v), `"'`); v != "" {
+				info.Version = v + " (rev " + revision + ")"
+			}
+			break
+		}
+	}
+	return info
+}
+
+// containsLine reports whether any candidate appears as a whole line in data
+func containsLine(data []byte, candidates []string) bool {
+	for _, c := range candidates {
+		needle := []byte(c)
+		for i := 0; ; {
+			j := bytes.Index(data[i:], needle)
+			if j < 0 {
+				break
+			}
+			start, end := i+j, i+j+len(needle)
+			if (start == 0 || data[start-1] == '\n') && (end == len(data) || data[end] == '\n') {
+				return true
+			}
+			i = end
+		}
+	}
+	return false
+}
@@ -0,0 +1,24 @@
+//go:build linux
+
+package source
+
+import (
+	"fmt"
+	"os"
+)
+
+// processRoot returns /proc/<pid>/root when it can be traversed, so package
+// databases are read from the process's mount namespace.
+func processRoot(pid int) (string, bool) {
+	root := fmt.Sprintf("/proc/%d/root", pid)
+	if _, err := os.Stat(root + "/"); err != nil {
+		return "/", false
+	}
+	return root, true
+}
+
+// runningExePath reads through /proc so the executable the process actually
+// mapped is hashed even after the file on disk was replaced.
+func runningExePath(pid int, exe string) string {
+	return fmt.Sprintf("/proc/%d/exe", pid)
+}
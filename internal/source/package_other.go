@@ -0,0 +1,11 @@
+//go:build !linux
+
+package source
+
+func processRoot(pid int) (string, bool) {
+	return "/", true
+}
+
+func runningExePath(pid int, exe string) string {
+	return exe
+}
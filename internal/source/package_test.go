@@ -0,0 +1,190 @@
+package source
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+func writeFixture(t *testing.T, root, rel, content string) {
+	t.Helper()
+	p := filepath.Join(root, rel)
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(p, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestResolvePackageDpkg(t *testing.T) {
+	// Without dpkg-query the file lists are scanned
+	orig := dpkgSearch
+	t.Cleanup(func() { dpkgSearch = orig })
+	dpkgSearch = func(string, []string) ([]byte, error) { return nil, errors.New("dpkg-query not found") }
+
+	root := t.TempDir()
+	writeFixture(t, root, "var/lib/dpkg/info/nginx-common.list", "/.\n/etc\n/etc/nginx/nginx.conf\n")
+	writeFixture(t, root, "var/lib/dpkg/info/nginx-core:amd64.list", "/.\n/usr\n/usr/sbin\n/usr/sbin/nginx\n")
+	writeFixture(t, root, "var/lib/dpkg/info/coreutils.list", "/.\n/bin\n/bin/sleep\n/bin/sleeper-not\n")
+	writeFixture(t, root, "var/lib/dpkg/status", `Package: nginx-core
+Status: install ok installed
+Architecture: i386
+Version: 1.20.0-1
+
+Package: nginx-core
+Status: install ok installed
+Architecture: amd64
+Version: 1.24.0-2
+
+Package: coreutils
+Architecture: amd64
+Version: 9.1-1
+`)
+
+	got := resolvePackage(root, "/usr/sbin/nginx")
+	want := &model.PackageInfo{Name: "nginx-core", Version: "1.24.0-2", Manager: "dpkg"}
+	if got == nil || *got != *want {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+
+	// merged-/usr: the running path is /usr/bin but dpkg recorded /bin
+	got = resolvePackage(root, "/usr/bin/sleep")
+	if got == nil || got.Name != "coreutils" || got.Version != "9.1-1" {
+		t.Fatalf("expected coreutils via /bin alias, got %+v", got)
+	}
+
+	if got := resolvePackage(root, "/usr/bin/sleeper"); got != nil {
+		t.Fatalf("expected no owner for a path prefix match, got %+v", got)
+	}
+}
+
+func TestResolvePackageDpkgQuery(t *testing.T) {
+	root := t.TempDir()
+	writeFixture(t, root, "var/lib/dpkg/status", "Package: coreutils\nArchitecture: amd64\nVersion: 9.1-1\n")
+
+	orig := dpkgSearch
+	t.Cleanup(func() { dpkgSearch = orig })
+	var searched []string
+	dpkgSearch = func(admindir string, candidates []string) ([]byte, error) {
+		if want := filepath.Join(root, "var/lib/dpkg"); admindir != want {
+			t.Fatalf("admindir = %q, want %q", admindir, want)
+		}
+		searched = candidates
+		return []byte("diversion by dash from: /bin/sh\ncoreutils, coreutils-extra: /bin/sleep\n"), nil
+	}
+
+	got := resolvePackage(root, "/usr/bin/sleep")
+	want := &model.PackageInfo{Name: "coreutils", Version: "9.1-1", Manager: "dpkg"}
+	if got == nil || *got != *want {
+		t.Fatalf("got %+v, want %+v (searched %v)", got, want, searched)
+	}
+
+	dpkgSearch = func(string, []string) ([]byte, error) { return nil, nil }
+	if got := resolvePackage(root, "/usr/bin/unowned"); got != nil {
+		t.Fatalf("expected no owner, got %+v", got)
+	}
+}
+
+func TestScanDpkgListsLikelyFirst(t *testing.T) {
+	// Both claim the file; the package named like it is read first
+	dir := t.TempDir()
+	writeFixture(t, dir, "aaa-other.list", "/usr/sbin/nginx\n")
+	writeFixture(t, dir, "nginx-core:amd64.list", "/usr/sbin/nginx\n")
+
+	if got := scanDpkgLists(dir, []string{"/usr/sbin/nginx"}); got != "nginx-core:amd64" {
+		t.Fatalf("scanDpkgLists() = %q, want nginx-core:amd64", got)
+	}
+}
+
+func TestResolvePackageApk(t *testing.T) {
+	root := t.TempDir()
+	writeFixture(t, root, "lib/apk/db/installed", `C:Q1abc=
+P:musl
+V:1.2.4-r2
+F:lib
+R:ld-musl-x86_64.so.1
+
+C:Q1def=
+P:nginx
+V:1.24.0-r7
+F:usr/sbin
+R:nginx
+F:etc/nginx
+R:nginx.conf
+`)
+	got := resolvePackage(root, "/usr/sbin/nginx")
+	want := &model.PackageInfo{Name: "nginx", Version: "1.24.0-r7", Manager: "apk"}
+	if got == nil || *got != *want {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestResolvePackagePacman(t *testing.T) {
+	root := t.TempDir()
+	writeFixture(t, root, "var/lib/pacman/local/nginx-1.24.0-1/desc", "%NAME%\nnginx\n\n%VERSION%\n1.24.0-1\n\n%ARCH%\nx86_64\n")
+	writeFixture(t, root, "var/lib/pacman/local/nginx-1.24.0-1/files", "%FILES%\nusr/\nusr/bin/\nusr/bin/nginx\n\n%BACKUP%\netc/nginx/nginx.conf\tabc\n")
+
+	got := resolvePackage(root, "/usr/bin/nginx")
+	want := &model.PackageInfo{Name: "nginx", Version: "1.24.0-1", Manager: "pacman"}
+	if got == nil || *got != *want {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestResolvePackageRpm(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "var/lib/rpm"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	orig := rpmQueryFile
+	t.Cleanup(func() { rpmQueryFile = orig })
+	var queried []string
+	rpmQueryFile = func(r, file string) ([]byte, error) {
+		if r != root {
+			t.Fatalf("rpm queried with root %q, want %q", r, root)
+		}
+		queried = append(queried, file)
+		if file == "/usr/sbin/nginx" {
+			return []byte("nginx\t1.24.0-1.el9\n"), nil
+		}
+		return nil, errors.New("file is not owned by any package")
+	}
+
+	got := resolvePackage(root, "/sbin/nginx")
+	want := &model.PackageInfo{Name: "nginx", Version: "1.24.0-1.el9", Manager: "rpm"}
+	if got == nil || *got != *want {
+		t.Fatalf("got %+v, want %+v (queried %v)", got, want, queried)
+	}
+}
+
+func TestResolvePackageStorePaths(t *testing.T) {
+	root := t.TempDir()
+	writeFixture(t, root, "snap/firefox/4336/meta/snap.yaml", "name: firefox\nversion: '124.0-2'\nsummary: browser\n")
+
+	tests := []struct {
+		exe  string
+		want model.PackageInfo
+	}{
+		{"/nix/store/8b0qzc5f3xvlyn0ixrsqbfbcwxk3k6i2-nginx-1.24.0/bin/nginx", model.PackageInfo{Name: "nginx", Version: "1.24.0", Manager: "nix"}},
+		{"/nix/store/0a2b3c4d5e6f7g8h9i0j1k2l3m4n5o6p-python3-3.11.8-env/bin/python", model.PackageInfo{Name: "python3", Version: "3.11.8-env", Manager: "nix"}},
+		{"/snap/firefox/4336/usr/lib/firefox/firefox", model.PackageInfo{Name: "firefox", Version: "124.0-2 (rev 4336)", Manager: "snap"}},
+		{"/snap/core22/1380/usr/bin/bash", model.PackageInfo{Name: "core22", Version: "rev 1380", Manager: "snap"}},
+		{"/usr/local/bin/api", model.PackageInfo{Manual: true, Prefix: "/usr/local"}},
+		{"/opt/app/bin/app", model.PackageInfo{Manual: true, Prefix: "/opt"}},
+	}
+	for _, tt := range tests {
+		got := resolvePackage(root, tt.exe)
+		if got == nil || *got != tt.want {
+			t.Errorf("%s: got %+v, want %+v", tt.exe, got, tt.want)
+		}
+	}
+
+	if got := resolvePackage(root, "/home/bob/bin/tool"); got != nil {
+		t.Errorf("expected unknown owner for home binary, got %+v", got)
+	}
+}
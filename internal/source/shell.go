@@ -54,6 +54,14 @@ var userTools = map[string]bool{
 }
 
 func detectShell(ancestry []model.Process) *model.Source {
+	src := findShell(ancestry)
+	if src != nil {
+		annotateSSH(src, ancestry)
+	}
+	return src
+}
+
+func findShell(ancestry []model.Process) *model.Source {
 	// Scan from the end (target) backwards to find the closest shell OR user tool
 	// This ensures we get the direct parent rather than an ancestor
 	for i := len(ancestry) - 1; i >= 0; i-- {
@@ -0,0 +1,62 @@
+package source
+
+import (
+	"slices"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+type signalConsequence struct {
+	signal  string
+	ignored string
+	caught  string
+}
+
+// terminationSignals explains what each disposition of a termination signal
+// means for someone trying to stop the process, in the order they are reported
+var terminationSignals = []signalConsequence{
+	{
+		signal:  "SIGHUP",
+		ignored: "survived terminal close (nohup?)",
+		caught:  "handles hangup itself (often reloads config instead of exiting)",
+	},
+	{
+		signal:  "SIGINT",
+		ignored: "Ctrl-C has no effect (started in the background by a script?)",
+		caught:  "handles Ctrl-C itself (may clean up first or refuse to exit)",
+	},
+	{
+		signal:  "SIGQUIT",
+		ignored: "Ctrl-\\ has no effect",
+		caught:  "handles Ctrl-\\ itself (may dump state instead of exiting)",
+	},
+	{
+		signal:  "SIGTERM",
+		ignored: "kill without -9 will not stop it; use SIGKILL",
+		caught:  "handles SIGTERM for a graceful shutdown (may take time or refuse)",
+	},
+}
+
+// ExplainSignals fills in the consequences of ignored, caught, blocked and
+// pending termination signals
+func ExplainSignals(si *model.SignalInfo) {
+	if si == nil {
+		return
+	}
+
+	si.Explanations = nil
+	for _, ts := range terminationSignals {
+		switch {
+		case slices.Contains(si.Ignored, ts.signal):
+			si.Explanations = append(si.Explanations, ts.signal+" ignored → "+ts.ignored)
+		case slices.Contains(si.Caught, ts.signal):
+			si.Explanations = append(si.Explanations, ts.signal+" caught → "+ts.caught)
+		}
+		if slices.Contains(si.Blocked, ts.signal) {
+			si.Explanations = append(si.Explanations, ts.signal+" blocked → delivery is deferred until the process unblocks it")
+		}
+		if slices.Contains(si.Pending, ts.signal) {
+			si.Explanations = append(si.Explanations, ts.signal+" pending → already sent but not yet acted on")
+		}
+	}
+}
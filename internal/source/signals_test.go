@@ -0,0 +1,30 @@
+package source
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+func TestExplainSignals(t *testing.T) {
+	si := &model.SignalInfo{
+		Ignored: []string{"SIGHUP", "SIGPIPE"},
+		Caught:  []string{"SIGTERM", "SIGCHLD"},
+		Pending: []string{"SIGTERM"},
+	}
+	ExplainSignals(si)
+
+	want := []string{
+		"SIGHUP ignored → survived terminal close (nohup?)",
+		"SIGTERM caught → handles SIGTERM for a graceful shutdown (may take time or refuse)",
+		"SIGTERM pending → already sent but not yet acted on",
+	}
+	if !slices.Equal(si.Explanations, want) {
+		t.Fatalf("ExplainSignals() = %q, want %q", si.Explanations, want)
+	}
+}
+
+func TestExplainSignalsNil(t *testing.T) {
+	ExplainSignals(nil)
+}
@@ -0,0 +1,182 @@
+package source
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+// sshSession describes the SSH login a process was started from
+type sshSession struct {
+	PID     int // sshd session process
+	User    string
+	Client  string // client IP address
+	TTY     string // e.g. "pts/3", empty for sessions without a terminal
+	LoginAt time.Time
+}
+
+// loginRecord is a USER_PROCESS entry from utmp/wtmp
+type loginRecord struct {
+	PID  int
+	Line string
+	User string
+	Host string
+	Time time.Time
+}
+
+// detectSSH walks the ancestry for an sshd session process and reads the
+// connection details from its environment or that of the first shell below it.
+func detectSSH(ancestry []model.Process) *sshSession {
+	idx := -1
+	for i := len(ancestry) - 1; i >= 0; i-- {
+		if isSSHDSession(ancestry[i]) {
+			idx = i
+			break
+		}
+	}
+	if idx == -1 {
+		return nil
+	}
+
+	sshd := ancestry[idx]
+	session := &sshSession{PID: sshd.PID}
+
+	// "sshd: alice@pts/3" or "sshd-session: alice@notty"
+	if _, rest, ok := strings.Cut(sshd.Cmdline, ": "); ok {
+		rest = strings.TrimSpace(rest)
+		if user, tty, ok := strings.Cut(rest, "@"); ok {
+			session.User = user
+			if f := strings.Fields(tty); len(f) > 0 && f[0] != "notty" {
+				session.TTY = f[0]
+			}
+		} else if fields := strings.Fields(rest); len(fields) > 0 {
+			session.User = fields[0]
+		}
+	}
+
+	for _, p := range ancestry[idx:] {
+		if client := sshClientFromEnv(p.Env); client != "" {
+			session.Client = client
+			if session.User == "" {
+				session.User = p.User
+			}
+			break
+		}
+	}
+
+	// Correlate with the login accounting records for the login time and TTY
+	if rec := findLoginRecord(session); rec != nil {
+		session.LoginAt = rec.Time
+		if session.TTY == "" {
+			session.TTY = rec.Line
+		}
+		if session.Client == "" {
+			session.Client = rec.Host
+		}
+		if session.User == "" {
+			session.User = rec.User
+		}
+	}
+
+	return session
+}
+
+func isSSHDSession(p model.Process) bool {
+	switch p.Command {
+	case "sshd", "sshd-session":
+		// The listener ("sshd -D" or "/usr/sbin/sshd") has no user in its title
+		return strings.Contains(p.Cmdline, "@") || strings.HasSuffix(p.Cmdline, "[priv]")
+	}
+	return false
+}
+
+// sshClientFromEnv extracts the client address from SSH_CONNECTION or SSH_CLIENT
+func sshClientFromEnv(env []string) string {
+	for _, key := range []string{"SSH_CONNECTION=", "SSH_CLIENT="} {
+		for _, e := range env {
+			if value, ok := strings.CutPrefix(e, key); ok {
+				if fields := strings.Fields(value); len(fields) > 0 {
+					return fields[0]
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// findLoginRecord matches the session by TTY and user, or by sshd PID,
+// against the current sessions in utmp, falling back to the wtmp history
+func findLoginRecord(s *sshSession) *loginRecord {
+	return findLogin(func(rec loginRecord) bool {
+		switch {
+		case s.TTY != "" && rec.Line == s.TTY && (s.User == "" || rec.User == s.User):
+			return true
+		case rec.PID == s.PID:
+			return true
+		}
+		return false
+	})
+}
+
+// latestLogin keeps the most recent of the records accepted by match, as a
+// TTY may be reused by later logins
+func latestLogin(records []loginRecord, match func(loginRecord) bool) *loginRecord {
+	var best *loginRecord
+	for i := range records {
+		if match(records[i]) && (best == nil || records[i].Time.After(best.Time)) {
+			best = &records[i]
+		}
+	}
+	return best
+}
+
+// annotateSSH adds SSH provenance to a shell source
+func annotateSSH(src *model.Source, ancestry []model.Process) {
+	s := detectSSH(ancestry)
+	if s == nil {
+		return
+	}
+
+	desc := "started from SSH session"
+	if s.User != "" {
+		desc += " by " + s.User
+	}
+	if s.Client != "" {
+		desc += " from " + s.Client
+	}
+	if !s.LoginAt.IsZero() {
+		desc += " at " + formatLoginTime(s.LoginAt)
+	}
+	if s.TTY != "" {
+		desc += " on " + s.TTY
+	}
+	src.Description = desc
+
+	if src.Details == nil {
+		src.Details = map[string]string{}
+	}
+	src.Details["ssh_pid"] = strconv.Itoa(s.PID)
+	if s.User != "" {
+		src.Details["ssh_user"] = s.User
+	}
+	if s.Client != "" {
+		src.Details["ssh_client"] = s.Client
+	}
+	if s.TTY != "" {
+		src.Details["ssh_tty"] = s.TTY
+	}
+	if !s.LoginAt.IsZero() {
+		src.Details["ssh_login"] = s.LoginAt.Format(time.RFC3339)
+	}
+}
+
+// formatLoginTime shows just the clock time for logins today
+func formatLoginTime(t time.Time) string {
+	now := time.Now()
+	if t.YearDay() == now.YearDay() && t.Year() == now.Year() {
+		return t.Format("15:04")
+	}
+	return t.Format("Jan 2 15:04")
+}
@@ -0,0 +1,85 @@
+package source
+
+import (
+	"testing"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+func TestDetectSSH(t *testing.T) {
+	ancestry := []model.Process{
+		{PID: 1, Command: "systemd"},
+		{PID: 800, Command: "sshd", Cmdline: "sshd: /usr/sbin/sshd -D [listener] 0 of 10-100 startups"},
+		{PID: 4100, Command: "sshd", Cmdline: "sshd: alice [priv]"},
+		{PID: 4120, Command: "sshd", Cmdline: "sshd: alice@pts/3"},
+		{PID: 4121, Command: "bash", User: "alice", Env: []string{"HOME=/home/alice", "SSH_CONNECTION=10.1.2.3 51234 10.0.0.5 22"}},
+		{PID: 4200, Command: "python3"},
+	}
+
+	s := detectSSH(ancestry)
+	if s == nil {
+		t.Fatal("expected SSH session")
+	}
+	if s.PID != 4120 || s.User != "alice" || s.TTY != "pts/3" || s.Client != "10.1.2.3" {
+		t.Fatalf("unexpected session: %+v", s)
+	}
+}
+
+func TestDetectSSHNoTTY(t *testing.T) {
+	ancestry := []model.Process{
+		{PID: 900, Command: "sshd-session", Cmdline: "sshd-session: deploy@notty"},
+		{PID: 901, Command: "sh", Env: []string{"SSH_CLIENT=192.0.2.7 40000 22"}},
+	}
+
+	s := detectSSH(ancestry)
+	if s == nil {
+		t.Fatal("expected SSH session")
+	}
+	if s.User != "deploy" || s.TTY != "" || s.Client != "192.0.2.7" {
+		t.Fatalf("unexpected session: %+v", s)
+	}
+}
+
+func TestDetectSSHEmptyTTY(t *testing.T) {
+	// Any user can name a process "sshd: x@"
+	ancestry := []model.Process{
+		{PID: 950, Command: "sshd", Cmdline: "sshd: x@"},
+		{PID: 951, Command: "sh"},
+	}
+
+	s := detectSSH(ancestry)
+	if s == nil {
+		t.Fatal("expected SSH session")
+	}
+	if s.User != "x" || s.TTY != "" {
+		t.Fatalf("unexpected session: %+v", s)
+	}
+}
+
+func TestDetectSSHListenerOnly(t *testing.T) {
+	ancestry := []model.Process{
+		{PID: 1, Command: "systemd"},
+		{PID: 800, Command: "sshd", Cmdline: "/usr/sbin/sshd -D"},
+	}
+	if s := detectSSH(ancestry); s != nil {
+		t.Fatalf("listener alone should not count as a session: %+v", s)
+	}
+}
+
+func TestAnnotateSSH(t *testing.T) {
+	ancestry := []model.Process{
+		{PID: 4120, Command: "sshd", Cmdline: "sshd: alice@pts/3"},
+		{PID: 4121, Command: "bash", Env: []string{"SSH_CONNECTION=10.1.2.3 51234 10.0.0.5 22"}},
+	}
+	src := detectShell(ancestry)
+	if src == nil {
+		t.Fatal("expected shell source")
+	}
+	if src.Details["ssh_user"] != "alice" || src.Details["ssh_client"] != "10.1.2.3" {
+		t.Fatalf("unexpected details: %v", src.Details)
+	}
+	want := "started from SSH session by alice from 10.1.2.3"
+	if len(src.Description) < len(want) || src.Description[:len(want)] != want {
+		t.Fatalf("unexpected description: %q", src.Description)
+	}
+}
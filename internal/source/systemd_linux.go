@@ -6,8 +6,12 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"path"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/pranshuparmar/witr/internal/systemd"
 	"github.com/pranshuparmar/witr/pkg/model"
 )
 
@@ -27,58 +31,176 @@ func detectSystemd(ancestry []model.Process) *model.Source {
 
 	// 2. Resolve the unit file for the target process (last in user's request chain)
 	targetProc := ancestry[len(ancestry)-1]
-	unitFile := resolveUnitFile(targetProc.PID)
-	description := resolveUnitDescription(targetProc.PID)
+	m := managerForPID(targetProc.PID)
+	unitFile := m.resolveUnitFile(targetProc.PID)
+	description := m.resolveUnitDescription(targetProc.PID)
+
+	name := "systemd"
+	var details map[string]string
+	unit := getUnitNameFromCgroup(targetProc.PID)
+	if strings.HasSuffix(unit, ".service") {
+		props := m.unitProperties(unit, unitExplainProperties)
+		details = unitDetails(props)
+		explainEnablement("/", m.paths, unit, details)
+		if trigger := m.resolveTrigger(unit, props); trigger != "" {
+			t := systemd.TriggerFromProperties(trigger, m.unitProperties(trigger, systemd.TriggerProperties))
+			triggerDetails(t, time.Now(), details)
+		}
+	}
+	if m.uid >= 0 {
+		name = "systemd --user"
+		details = userUnitDetails(m.user, unit, details)
+		if unitFile == "" && unit != "" {
+			unitFile = findUnitFile("/", m.paths, unit)
+		}
+	}
 
 	return &model.Source{
 		Type:        model.SourceSystemd,
-		Name:        "systemd",
+		Name:        name,
 		Description: description,
 		UnitFile:    unitFile,
+		Details:     details,
 	}
 }
 
-func resolveUnitDescription(pid int) string {
-	if _, err := exec.LookPath("systemctl"); err != nil {
-		return ""
+// systemdManager is the systemd instance a unit belongs to: the system
+// manager, or the systemd --user instance running as user@<uid>.service
+type systemdManager struct {
+	uid   int // -1 for the system manager
+	user  string
+	paths unitPaths
+}
+
+var systemManager = systemdManager{uid: -1, paths: systemUnitPaths}
+
+// managerForPID picks the user manager when pid runs below user@<uid>.service
+func managerForPID(pid int) systemdManager {
+	data, err := os.ReadFile(fmt.Sprintf("/proc/%d/cgroup", pid))
+	if err != nil {
+		return systemManager
+	}
+	uid, ok := userManagerUID(string(data))
+	if !ok {
+		return systemManager
+	}
+	name, home := lookupAccount(uid)
+	return systemdManager{uid: uid, user: name, paths: userUnitPaths(home)}
+}
+
+func (m systemdManager) client() (*systemd.Client, error) {
+	if m.uid < 0 {
+		return systemd.System()
+	}
+	return systemd.User(m.uid)
+}
+
+// systemctl runs systemctl against the manager; user instances are reached
+// through the machine transport as "<uid>@"
+func (m systemdManager) systemctl(args ...string) ([]byte, error) {
+	if m.uid >= 0 {
+		args = append([]string{"--user", "-M", strconv.Itoa(m.uid) + "@"}, args...)
 	}
+	return exec.Command("systemctl", args...).Output()
+}
 
+// resolveTrigger returns the timer, socket or path unit that activates unit,
+// from its TriggeredBy= property or, on managers without it, by scanning the
+// Triggers= of loaded units.
+func (m systemdManager) resolveTrigger(unit string, props map[string]any) string {
+	triggers := propStrings(props["TriggeredBy"])
+	if _, ok := props["TriggeredBy"]; !ok {
+		if c, err := m.client(); err == nil {
+			triggers, _ = c.TriggersOf(unit)
+		}
+	}
+	for _, t := range triggers {
+		switch path.Ext(t) {
+		case ".timer", ".socket", ".path":
+			return t
+		}
+	}
+	return ""
+}
+
+func (m systemdManager) resolveUnitDescription(pid int) string {
 	unitName := getUnitNameFromCgroup(pid)
 	if unitName != "" {
-		if desc := querySystemdProperty("Description", unitName); desc != "" {
+		if desc := m.queryProperty("Description", unitName); desc != "" {
 			return desc
 		}
 	}
-	if desc := querySystemdProperty("Description", fmt.Sprintf("%d", pid)); desc != "" {
+	if desc := m.queryProperty("Description", fmt.Sprintf("%d", pid)); desc != "" {
 		return desc
 	}
 	return ""
 }
 
-func resolveUnitFile(pid int) string {
-	if _, err := exec.LookPath("systemctl"); err != nil {
-		return ""
-	}
-
+func (m systemdManager) resolveUnitFile(pid int) string {
 	unitName := getUnitNameFromCgroup(pid)
 
 	if unitName != "" {
-		if path := querySystemdProperty("FragmentPath", unitName); path != "" {
+		if path := m.queryProperty("FragmentPath", unitName); path != "" {
 			return path
 		}
-		if path := querySystemdProperty("SourcePath", unitName); path != "" {
+		if path := m.queryProperty("SourcePath", unitName); path != "" {
 			return path
 		}
 	}
-	if path := querySystemdProperty("FragmentPath", fmt.Sprintf("%d", pid)); path != "" {
+	if path := m.queryProperty("FragmentPath", fmt.Sprintf("%d", pid)); path != "" {
 		return path
 	}
-	return querySystemdProperty("SourcePath", fmt.Sprintf("%d", pid))
+	return m.queryProperty("SourcePath", fmt.Sprintf("%d", pid))
+}
+
+// property fetches a typed unit property over D-Bus. target is a unit
+// name or a PID; ok is false when the bus is unavailable.
+func (m systemdManager) property(prop, target string) (any, bool) {
+	c, err := m.client()
+	if err != nil {
+		return nil, false
+	}
+	unit := target
+	if pid, err := strconv.Atoi(target); err == nil {
+		if unit, err = c.UnitByPID(pid); err != nil {
+			return nil, false
+		}
+	}
+	v, err := c.Property(unit, prop)
+	if err != nil {
+		return nil, false
+	}
+	return v, true
 }
 
-func querySystemdProperty(prop, target string) string {
-	cmd := exec.Command("systemctl", "show", "-p", prop, "--value", target)
-	out, err := cmd.Output()
+// unitProperties fetches several properties at once: from the cached
+// D-Bus GetAll, or with a single `systemctl show -p ...` call.
+func (m systemdManager) unitProperties(unit string, props []string) map[string]any {
+	if c, err := m.client(); err == nil {
+		if all, err := c.Properties(unit); err == nil {
+			return all
+		}
+	}
+
+	args := []string{"show"}
+	for _, p := range props {
+		args = append(args, "-p", p)
+	}
+	out, err := m.systemctl(append(args, "--", unit)...)
+	if err != nil {
+		return nil
+	}
+	return parseSystemctlShow(string(out))
+}
+
+// queryProperty returns a property as `systemctl show --value` prints it,
+// over D-Bus when possible and through systemctl otherwise.
+func (m systemdManager) queryProperty(prop, target string) string {
+	if v, ok := m.property(prop, target); ok {
+		return systemd.FormatValue(v)
+	}
+
+	out, err := m.systemctl("show", "-p", prop, "--value", target)
 	if err != nil {
 		return ""
 	}
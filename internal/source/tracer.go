@@ -0,0 +1,45 @@
+package source
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+type tracerKind struct {
+	kind        string
+	description string
+}
+
+// knownTracers maps ptrace users to what attaching them usually means
+var knownTracers = map[string]tracerKind{
+	"gdb":         {"debugger", "Interactive debugger attached (execution may be paused at a breakpoint)"},
+	"gdbserver":   {"debugger", "Remote debugging stub attached"},
+	"lldb":        {"debugger", "Interactive debugger attached (execution may be paused at a breakpoint)"},
+	"lldb-server": {"debugger", "Remote debugging stub attached"},
+	"dlv":         {"debugger", "Delve Go debugger attached"},
+	"rr":          {"debugger", "Being recorded by rr for replay debugging"},
+	"strace":      {"syscall tracer", "System calls are being traced (expect a heavy slowdown)"},
+	"ltrace":      {"library call tracer", "Library calls are being traced (expect a heavy slowdown)"},
+	"perf":        {"profiler", "Being profiled by perf"},
+	"py-spy":      {"profiler", "Being sampled by the py-spy Python profiler"},
+	"rbspy":       {"profiler", "Being sampled by the rbspy Ruby profiler"},
+	"valgrind":    {"profiler", "Running under valgrind instrumentation"},
+	"criu":        {"checkpointer", "Being checkpointed or restored by CRIU"},
+}
+
+// ClassifyTracer fills in the kind and description of a tracer from its command
+func ClassifyTracer(t *model.TracerInfo) {
+	if t == nil {
+		return
+	}
+	name := strings.ToLower(filepath.Base(t.Command))
+	if k, ok := knownTracers[name]; ok {
+		t.Kind = k.kind
+		t.Description = k.description
+		return
+	}
+	t.Kind = "unknown"
+	t.Description = "Unrecognized tracer; could be anti-debugging, instrumentation or code injection"
+}
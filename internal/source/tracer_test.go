@@ -0,0 +1,32 @@
+package source
+
+import (
+	"testing"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+func TestClassifyTracer(t *testing.T) {
+	tests := []struct {
+		command string
+		kind    string
+	}{
+		{"gdb", "debugger"},
+		{"/usr/bin/strace", "syscall tracer"},
+		{"ltrace", "library call tracer"},
+		{"py-spy", "profiler"},
+		{"CRIU", "checkpointer"},
+		{"injector", "unknown"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.command, func(t *testing.T) {
+			info := &model.TracerInfo{Command: tt.command}
+			ClassifyTracer(info)
+			if info.Kind != tt.kind || info.Description == "" {
+				t.Errorf("ClassifyTracer(%q) = %q %q, want kind %q", tt.command, info.Kind, info.Description, tt.kind)
+			}
+		})
+	}
+
+	ClassifyTracer(nil)
+}
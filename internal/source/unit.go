@@ -0,0 +1,244 @@
+package source
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pranshuparmar/witr/internal/systemd"
+)
+
+// unitExplainProperties are the unit properties behind the "Unit" block
+var unitExplainProperties = []string{
+	"Id", "ActiveState", "SubState", "ExecStartPre", "ExecStart", "User", "Group",
+	"Restart", "RestartUSec", "WorkingDirectory", "EnvironmentFiles", "DropInPaths",
+	"TriggeredBy",
+}
+
+// unitDetails turns unit properties into Source.Details entries. Values are
+// either typed D-Bus values or the text printed by `systemctl show`.
+func unitDetails(props map[string]any) map[string]string {
+	d := make(map[string]string)
+	set := func(key, value string) {
+		if value != "" {
+			d[key] = value
+		}
+	}
+
+	set("unit", propString(props["Id"]))
+	state := propString(props["ActiveState"])
+	if sub := propString(props["SubState"]); sub != "" && state != "" {
+		state += " (" + sub + ")"
+	}
+	set("state", state)
+	set("exec_start_pre", strings.Join(execCommands(props["ExecStartPre"]), "\n"))
+	set("exec_start", strings.Join(execCommands(props["ExecStart"]), "\n"))
+	set("user", propString(props["User"]))
+	set("group", propString(props["Group"]))
+	set("restart", propString(props["Restart"]))
+	set("restart_sec", propDuration(props["RestartUSec"]))
+	set("working_directory", propString(props["WorkingDirectory"]))
+	set("environment_file", strings.Join(environmentFilePaths(props["EnvironmentFiles"]), "\n"))
+
+	var dropIns []string
+	for _, path := range propStrings(props["DropInPaths"]) {
+		entry := path
+		if data, err := os.ReadFile(path); err == nil {
+			if overrides := dropInDirectives(string(data)); len(overrides) > 0 {
+				entry += " (" + strings.Join(overrides, "; ") + ")"
+			}
+		}
+		dropIns = append(dropIns, entry)
+	}
+	set("drop_ins", strings.Join(dropIns, "\n"))
+	return d
+}
+
+// triggerDetails records the timer, socket or path unit that started a service:
+// "trigger" is its name, "trigger_summary" the one-line description shown on the
+// Source line and "trigger_detail" its schedule, listen addresses or watched paths.
+func triggerDetails(t systemd.Trigger, now time.Time, details map[string]string) {
+	details["trigger"] = t.Unit
+	if summary := t.Summary(now); summary != "" {
+		details["trigger_summary"] = summary
+	}
+	if lines := t.Details(); len(lines) > 0 {
+		details["trigger_detail"] = strings.Join(lines, "\n")
+	}
+}
+
+func propString(v any) string {
+	switch x := v.(type) {
+	case string:
+		return x
+	case nil:
+		return ""
+	}
+	return fmt.Sprint(v)
+}
+
+func propStrings(v any) []string {
+	switch x := v.(type) {
+	case string:
+		return strings.Fields(x)
+	case []any:
+		var out []string
+		for _, item := range x {
+			if s, ok := item.(string); ok && s != "" {
+				out = append(out, s)
+			}
+		}
+		return out
+	}
+	return nil
+}
+
+// propDuration formats a microsecond count the way systemctl does ("5s", "100ms")
+func propDuration(v any) string {
+	switch x := v.(type) {
+	case string:
+		return x
+	case uint64:
+		if x == 0 {
+			return ""
+		}
+		if x == ^uint64(0) {
+			return "infinity"
+		}
+		return (time.Duration(x) * time.Microsecond).String()
+	}
+	return ""
+}
+
+// execCommands lists the command lines of an Exec*= property. Over D-Bus it
+// is a(sasbttttuii) of path, argv, ignore-failure and timing; systemctl prints
+// "{ path=/bin/x ; argv[]=/bin/x -a ; ignore_errors=no ; ... }" per command.
+// Commands whose failure is ignored get systemd's "-" prefix.
+func execCommands(v any) []string {
+	var cmds []string
+	switch x := v.(type) {
+	case []any:
+		for _, e := range x {
+			fields, ok := e.([]any)
+			if !ok || len(fields) < 3 {
+				continue
+			}
+			argv := propStrings(fields[1])
+			if len(argv) == 0 {
+				if path, ok := fields[0].(string); ok {
+					argv = []string{path}
+				}
+			}
+			cmd := strings.Join(argv, " ")
+			if ignore, _ := fields[2].(bool); ignore {
+				cmd = "-" + cmd
+			}
+			cmds = append(cmds, cmd)
+		}
+	case string:
+		rest := x
+		for {
+			_, after, ok := strings.Cut(rest, "argv[]=")
+			if !ok {
+				break
+			}
+			argv, tail, _ := strings.Cut(after, " ; ignore_errors=")
+			cmd := strings.TrimSpace(argv)
+			if strings.HasPrefix(tail, "yes") {
+				cmd = "-" + cmd
+			}
+			if cmd != "" {
+				cmds = append(cmds, cmd)
+			}
+			rest = tail
+		}
+	}
+	return cmds
+}
+
+// environmentFilePaths lists EnvironmentFile= paths from a(sb) or from
+// systemctl's "/etc/default/foo (ignore_errors=yes)" lines
+func environmentFilePaths(v any) []string {
+	var paths []string
+	switch x := v.(type) {
+	case []any:
+		for _, e := range x {
+			if fields, ok := e.([]any); ok && len(fields) > 0 {
+				if path, ok := fields[0].(string); ok && path != "" {
+					paths = append(paths, path)
+				}
+			}
+		}
+	case string:
+		for line := range strings.Lines(x) {
+			if path, _, _ := strings.Cut(strings.TrimSpace(line), " "); path != "" {
+				paths = append(paths, path)
+			}
+		}
+	}
+	return paths
+}
+
+// dropInDirectives summarises which directives a drop-in sets, per section:
+// "[Service] Restart, RestartSec". An empty assignment resets a list such as
+// ExecStart= and is marked as such.
+func dropInDirectives(data string) []string {
+	var order []string
+	bySection := make(map[string][]string)
+	section := ""
+	scanner := bufio.NewScanner(strings.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || line[0] == '#' || line[0] == ';' {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			section = line
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		key = strings.TrimSpace(key)
+		if strings.TrimSpace(value) == "" {
+			key += " (reset)"
+		}
+		if _, seen := bySection[section]; !seen {
+			order = append(order, section)
+		}
+		if !slices.Contains(bySection[section], key) {
+			bySection[section] = append(bySection[section], key)
+		}
+	}
+
+	var out []string
+	for _, s := range order {
+		entry := strings.Join(bySection[s], ", ")
+		if s != "" {
+			entry = s + " " + entry
+		}
+		out = append(out, entry)
+	}
+	return out
+}
+
+// parseSystemctlShow parses `systemctl show -p A -p B` output. Repeated keys
+// (one line per ExecStartPre= command) are joined with newlines.
+func parseSystemctlShow(out string) map[string]any {
+	props := make(map[string]any)
+	for line := range strings.Lines(out) {
+		key, value, ok := strings.Cut(strings.TrimRight(line, "\n"), "=")
+		if !ok || value == "" {
+			continue
+		}
+		if prev, ok := props[key].(string); ok {
+			value = prev + "\n" + value
+		}
+		props[key] = value
+	}
+	return props
+}
@@ -0,0 +1,102 @@
+package source
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestUnitDetailsFromDBus(t *testing.T) {
+	dropIn := filepath.Join(t.TempDir(), "override.conf")
+	if err := os.WriteFile(dropIn, []byte("# keep it up\n[Service]\nRestart=always\nRestartSec=5\nExecStart=\nExecStart=/usr/sbin/nginx -g 'daemon off;'\n\n[Unit]\nStartLimitBurst=0\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	props := map[string]any{
+		"Id":          "nginx.service",
+		"ActiveState": "active",
+		"SubState":    "running",
+		"ExecStartPre": []any{
+			[]any{"/usr/sbin/nginx", []any{"/usr/sbin/nginx", "-t", "-q"}, false, uint64(0)},
+			[]any{"/bin/mkdir", []any{"/bin/mkdir", "-p", "/run/nginx"}, true, uint64(0)},
+		},
+		"ExecStart":        []any{[]any{"/usr/sbin/nginx", []any{"/usr/sbin/nginx", "-g", "daemon off;"}, false}},
+		"User":             "www-data",
+		"Group":            "",
+		"Restart":          "always",
+		"RestartUSec":      uint64(5_000_000),
+		"WorkingDirectory": "",
+		"EnvironmentFiles": []any{[]any{"/etc/default/nginx", true}},
+		"DropInPaths":      []any{dropIn},
+	}
+
+	d := unitDetails(props)
+	want := map[string]string{
+		"unit":             "nginx.service",
+		"state":            "active (running)",
+		"exec_start_pre":   "/usr/sbin/nginx -t -q\n-/bin/mkdir -p /run/nginx",
+		"exec_start":       "/usr/sbin/nginx -g daemon off;",
+		"user":             "www-data",
+		"restart":          "always",
+		"restart_sec":      "5s",
+		"environment_file": "/etc/default/nginx",
+		"drop_ins":         dropIn + " ([Service] Restart, RestartSec, ExecStart (reset), ExecStart; [Unit] StartLimitBurst)",
+	}
+	for k, v := range want {
+		if d[k] != v {
+			t.Errorf("%s = %q, want %q", k, d[k], v)
+		}
+	}
+	if _, ok := d["group"]; ok {
+		t.Errorf("expected empty Group to be omitted, got %q", d["group"])
+	}
+	if _, ok := d["working_directory"]; ok {
+		t.Errorf("expected empty WorkingDirectory to be omitted")
+	}
+}
+
+func TestUnitDetailsFromSystemctl(t *testing.T) {
+	out := `Id=api.service
+ActiveState=activating
+SubState=auto-restart
+ExecStartPre={ path=/usr/bin/env ; argv[]=/usr/bin/env true ; ignore_errors=yes ; start_time=[n/a] ; stop_time=[n/a] ; pid=0 ; code=(null) ; status=0/0 }
+ExecStart={ path=/srv/api/bin/api ; argv[]=/srv/api/bin/api --port 8080 ; ignore_errors=no ; start_time=[Mon 2026-10-19 10:00:00 UTC] ; stop_time=[n/a] ; pid=812 ; code=(null) ; status=0/0 }
+User=api
+Group=api
+Restart=on-failure
+RestartUSec=100ms
+WorkingDirectory=/srv/api
+EnvironmentFiles=/etc/default/api (ignore_errors=no)
+DropInPaths=
+`
+	d := unitDetails(parseSystemctlShow(out))
+	want := map[string]string{
+		"unit":              "api.service",
+		"state":             "activating (auto-restart)",
+		"exec_start_pre":    "-/usr/bin/env true",
+		"exec_start":        "/srv/api/bin/api --port 8080",
+		"user":              "api",
+		"group":             "api",
+		"restart":           "on-failure",
+		"restart_sec":       "100ms",
+		"working_directory": "/srv/api",
+		"environment_file":  "/etc/default/api",
+	}
+	for k, v := range want {
+		if d[k] != v {
+			t.Errorf("%s = %q, want %q", k, d[k], v)
+		}
+	}
+	if _, ok := d["drop_ins"]; ok {
+		t.Errorf("expected no drop-ins, got %q", d["drop_ins"])
+	}
+}
+
+func TestParseSystemctlShowRepeatedKeys(t *testing.T) {
+	props := parseSystemctlShow("ExecStartPre={ path=/a ; argv[]=/a ; ignore_errors=no }\nExecStartPre={ path=/b ; argv[]=/b x ; ignore_errors=no }\n")
+	got := execCommands(props["ExecStartPre"])
+	if !slices.Equal(got, []string{"/a", "/b x"}) {
+		t.Fatalf("got %q", got)
+	}
+}
@@ -0,0 +1,53 @@
+package source
+
+import (
+	"os/user"
+	"strconv"
+	"strings"
+)
+
+// userManagerUID finds the systemd --user instance a process runs under from
+// the contents of /proc/<pid>/cgroup: units of that instance live below
+// .../user@<uid>.service/ in the unified or name=systemd hierarchy.
+func userManagerUID(cgroup string) (int, bool) {
+	for line := range strings.Lines(cgroup) {
+		parts := strings.SplitN(strings.TrimSpace(line), ":", 3)
+		if len(parts) < 3 || (parts[1] != "" && !strings.Contains(parts[1], "systemd")) {
+			continue
+		}
+		for _, part := range strings.Split(parts[2], "/") {
+			if rest, ok := strings.CutPrefix(part, "user@"); ok {
+				if uid, err := strconv.Atoi(strings.TrimSuffix(rest, ".service")); err == nil && strings.HasSuffix(rest, ".service") {
+					return uid, true
+				}
+			}
+		}
+	}
+	return 0, false
+}
+
+// lookupAccount returns the user name and home directory of uid, or the
+// numeric uid and "" when it has no passwd entry
+func lookupAccount(uid int) (name, home string) {
+	u, err := user.LookupId(strconv.Itoa(uid))
+	if err != nil {
+		return strconv.Itoa(uid), ""
+	}
+	return u.Username, u.HomeDir
+}
+
+// userUnitDetails records the user manager a unit belongs to. Its units run
+// as the manager's owner unless User= says otherwise.
+func userUnitDetails(user, unit string, details map[string]string) map[string]string {
+	if details == nil {
+		details = map[string]string{}
+	}
+	details["user_manager"] = user
+	if details["user"] == "" {
+		details["user"] = user
+	}
+	if details["unit"] == "" && unit != "" {
+		details["unit"] = unit
+	}
+	return details
+}
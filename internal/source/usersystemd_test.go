@@ -0,0 +1,72 @@
+package source
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestUserManagerUID(t *testing.T) {
+	tests := []struct {
+		cgroup string
+		uid    int
+		ok     bool
+	}{
+		{"0::/user.slice/user-1000.slice/user@1000.service/session.slice/pipewire.service\n", 1000, true},
+		{"0::/user.slice/user-1001.slice/user@1001.service/app.slice/podman-foo.service\n", 1001, true},
+		{"12:cpu:/user.slice\n1:name=systemd:/user.slice/user-42.slice/user@42.service/init.scope\n", 42, true},
+		{"0::/system.slice/nginx.service\n", 0, false},
+		{"0::/user.slice/user-1000.slice/session-3.scope\n", 0, false},
+		{"5:cpu:/user.slice/user-7.slice/user@7.service\n", 0, false},
+	}
+	for _, tt := range tests {
+		uid, ok := userManagerUID(tt.cgroup)
+		if uid != tt.uid || ok != tt.ok {
+			t.Errorf("userManagerUID(%q) = %d, %v; want %d, %v", tt.cgroup, uid, ok, tt.uid, tt.ok)
+		}
+	}
+}
+
+func TestUserUnitFileAndEnablement(t *testing.T) {
+	root := t.TempDir()
+	home := "/home/alice"
+	writeFixture(t, root, "usr/lib/systemd/user/pipewire.service", "[Service]\nExecStart=/usr/bin/pipewire\n[Install]\nWantedBy=default.target\n")
+	writeFixture(t, root, "home/alice/.config/systemd/user/sync.service", "[Service]\nExecStart=/home/alice/bin/sync\n")
+	writeFixture(t, root, "usr/lib/systemd/user/default.target", "[Unit]\n")
+	link := filepath.Join(root, "home/alice/.config/systemd/user/default.target.wants/pipewire.service")
+	if err := os.MkdirAll(filepath.Dir(link), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink("/usr/lib/systemd/user/pipewire.service", link); err != nil {
+		t.Fatal(err)
+	}
+
+	paths := userUnitPaths(home)
+	if got := findUnitFile(root, paths, "sync.service"); got != "/home/alice/.config/systemd/user/sync.service" {
+		t.Errorf("findUnitFile(sync) = %q", got)
+	}
+	if got := findUnitFile(root, paths, "pipewire.service"); got != "/usr/lib/systemd/user/pipewire.service" {
+		t.Errorf("findUnitFile(pipewire) = %q", got)
+	}
+	if got := findUnitFile(root, paths, "missing.service"); got != "" {
+		t.Errorf("findUnitFile(missing) = %q", got)
+	}
+
+	d := map[string]string{}
+	explainEnablement(root, paths, "pipewire.service", d)
+	if d["enabled"] != "enabled by user" || d["wanted_by"] != "default.target" || d["boot_path"] != "default.target → pipewire.service" {
+		t.Fatalf("unexpected details: %v", d)
+	}
+}
+
+func TestUserUnitDetails(t *testing.T) {
+	d := userUnitDetails("alice", "pipewire.service", map[string]string{"unit": "pipewire.service"})
+	if d["user"] != "alice" || d["user_manager"] != "alice" {
+		t.Fatalf("unexpected details: %v", d)
+	}
+
+	d = userUnitDetails("alice", "sync.service", map[string]string{"user": "backup"})
+	if d["user"] != "backup" || d["unit"] != "sync.service" {
+		t.Fatalf("User= should win over the manager's owner: %v", d)
+	}
+}
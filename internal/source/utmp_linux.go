@@ -0,0 +1,112 @@
+//go:build linux
+
+package source
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net"
+	"os"
+	"time"
+)
+
+// utmpPaths lists where the current sessions are recorded; wtmpPath keeps
+// the login history
+var (
+	utmpPaths = []string{"/var/run/utmp", "/run/utmp"}
+	wtmpPath  = "/var/log/wtmp"
+)
+
+const (
+	utmpRecordSize  = 384
+	utmpUserProcess = 7
+)
+
+// wtmpChunk is how many records are read at a time when scanning wtmp
+const wtmpChunk = 64
+
+// findLogin returns the most recent USER_PROCESS entry accepted by match from
+// the first utmp that has one. Only when no current session matches is the
+// login history in wtmp scanned, newest first.
+func findLogin(match func(loginRecord) bool) *loginRecord {
+	for _, path := range utmpPaths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		if rec := latestLogin(parseUtmp(data), match); rec != nil {
+			return rec
+		}
+	}
+	return searchWtmp(wtmpPath, match)
+}
+
+// searchWtmp reads wtmp backwards a chunk at a time and returns the last
+// entry accepted by match, so the usually large file is never loaded whole
+func searchWtmp(path string, match func(loginRecord) bool) *loginRecord {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return nil
+	}
+
+	buf := make([]byte, wtmpChunk*utmpRecordSize)
+	end := info.Size() - info.Size()%utmpRecordSize
+	for end > 0 {
+		start := max(end-int64(len(buf)), 0)
+		chunk := buf[:end-start]
+		if _, err := f.ReadAt(chunk, start); err != nil {
+			return nil
+		}
+		records := parseUtmp(chunk)
+		for i := len(records) - 1; i >= 0; i-- {
+			if match(records[i]) {
+				return &records[i]
+			}
+		}
+		end = start
+	}
+	return nil
+}
+
+// parseUtmp decodes glibc's struct utmp as laid out on 64-bit Linux:
+// type(4) pid(4) line(32) id(4) user(32) host(256) exit(4) session(4) tv(8) addr_v6(16) unused(20)
+func parseUtmp(data []byte) []loginRecord {
+	var records []loginRecord
+	for off := 0; off+utmpRecordSize <= len(data); off += utmpRecordSize {
+		rec := data[off : off+utmpRecordSize]
+		if int16(binary.LittleEndian.Uint16(rec[0:2])) != utmpUserProcess {
+			continue
+		}
+
+		r := loginRecord{
+			PID:  int(int32(binary.LittleEndian.Uint32(rec[4:8]))),
+			Line: cString(rec[8:40]),
+			User: cString(rec[44:76]),
+			Host: cString(rec[76:332]),
+			Time: time.Unix(int64(int32(binary.LittleEndian.Uint32(rec[340:344]))), 0),
+		}
+		if r.Host == "" {
+			if addr := rec[348:364]; !bytes.Equal(addr, make([]byte, 16)) {
+				if bytes.Equal(addr[4:], make([]byte, 12)) {
+					r.Host = net.IP(addr[:4]).String()
+				} else {
+					r.Host = net.IP(addr).String()
+				}
+			}
+		}
+		records = append(records, r)
+	}
+	return records
+}
+
+func cString(b []byte) string {
+	if i := bytes.IndexByte(b, 0); i >= 0 {
+		b = b[:i]
+	}
+	return string(b)
+}
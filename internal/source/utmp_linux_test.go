@@ -0,0 +1,64 @@
+//go:build linux
+
+package source
+
+import (
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func utmpRecord(typ int16, pid int32, line, user, host string, sec int32) []byte {
+	rec := make([]byte, utmpRecordSize)
+	binary.LittleEndian.PutUint16(rec[0:2], uint16(typ))
+	binary.LittleEndian.PutUint32(rec[4:8], uint32(pid))
+	copy(rec[8:40], line)
+	copy(rec[44:76], user)
+	copy(rec[76:332], host)
+	binary.LittleEndian.PutUint32(rec[340:344], uint32(sec))
+	return rec
+}
+
+func TestParseUtmp(t *testing.T) {
+	var data []byte
+	data = append(data, utmpRecord(8, 10, "pts/1", "", "", 1000)...) // DEAD_PROCESS
+	data = append(data, utmpRecord(utmpUserProcess, 4120, "pts/3", "alice", "10.1.2.3", 1700000000)...)
+	data = append(data, 0, 1, 2) // truncated trailer
+
+	records := parseUtmp(data)
+	if len(records) != 1 {
+		t.Fatalf("expected 1 record, got %d", len(records))
+	}
+	r := records[0]
+	if r.PID != 4120 || r.Line != "pts/3" || r.User != "alice" || r.Host != "10.1.2.3" {
+		t.Fatalf("unexpected record: %+v", r)
+	}
+	if r.Time.Unix() != 1700000000 {
+		t.Fatalf("unexpected time: %v", r.Time)
+	}
+}
+
+func TestSearchWtmp(t *testing.T) {
+	var data []byte
+	for i := range 3 * wtmpChunk {
+		data = append(data, utmpRecord(utmpUserProcess, int32(1000+i), "pts/3", "alice", "10.1.2.3", int32(1700000000+i))...)
+	}
+	data = append(data, utmpRecord(utmpUserProcess, 5000, "pts/4", "bob", "10.9.9.9", 1700009999)...)
+	path := filepath.Join(t.TempDir(), "wtmp")
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	rec := searchWtmp(path, func(r loginRecord) bool { return r.Line == "pts/3" })
+	if rec == nil || rec.PID != 1000+3*wtmpChunk-1 {
+		t.Fatalf("expected the newest pts/3 login, got %+v", rec)
+	}
+	rec = searchWtmp(path, func(r loginRecord) bool { return r.PID == 1000 })
+	if rec == nil || rec.Time.Unix() != 1700000000 {
+		t.Fatalf("expected the oldest login, got %+v", rec)
+	}
+	if rec := searchWtmp(path, func(r loginRecord) bool { return r.User == "carol" }); rec != nil {
+		t.Fatalf("unexpected match: %+v", rec)
+	}
+}
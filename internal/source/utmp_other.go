@@ -0,0 +1,7 @@
+//go:build !linux
+
+package source
+
+func findLogin(match func(loginRecord) bool) *loginRecord {
+	return nil
+}
@@ -0,0 +1,405 @@
+// Package systemd queries the systemd manager over D-Bus. One connection is
+// shared per bus and unit properties are fetched with a single GetAll call
+// and cached, replacing repeated `systemctl show` invocations.
+package systemd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	busName       = "org.freedesktop.systemd1"
+	managerPath   = "/org/freedesktop/systemd1"
+	managerIface  = "org.freedesktop.systemd1.Manager"
+	propertyIface = "org.freedesktop.DBus.Properties"
+)
+
+// Client is a connection to one systemd instance, system or per-user
+type Client struct {
+	conn *conn
+
+	mu    sync.Mutex
+	props map[string]map[string]any // unit name → all properties
+	paths map[string]string         // unit name → object path
+	names map[string]string         // object path → unit name
+}
+
+// Socket is a socket unit with its listen addresses and the units it activates
+type Socket struct {
+	Unit     string
+	Listen   []Listen
+	Triggers []string
+}
+
+// Listen is one ListenStream=/ListenDatagram=/... entry of a socket unit
+type Listen struct {
+	Type    string // Stream, Datagram, SequentialPacket, FIFO, ...
+	Address string
+}
+
+var (
+	clientsMu    sync.Mutex
+	systemClient *Client
+	systemErr    error
+	userClients  = map[int]*Client{}
+	userErrs     = map[int]error{}
+)
+
+// System returns the shared client for the system manager, connecting on first
+// use through the system bus or, without a bus daemon, systemd's private socket.
+// A failure is remembered until Invalidate so hosts without D-Bus fall back
+// cheaply, and a connection that broke is redialled.
+func System() (*Client, error) {
+	clientsMu.Lock()
+	defer clientsMu.Unlock()
+	if systemClient != nil && systemClient.conn.alive() {
+		return systemClient, nil
+	}
+	if systemErr != nil {
+		return nil, systemErr
+	}
+	if systemClient != nil {
+		systemClient.Close()
+		systemClient = nil
+	}
+
+	addr := os.Getenv("DBUS_SYSTEM_BUS_ADDRESS")
+	if addr == "" {
+		addr = "unix:path=/run/dbus/system_bus_socket;unix:path=/var/run/dbus/system_bus_socket"
+	}
+	c, err := Dial(addr)
+	if err != nil && os.Geteuid() == 0 {
+		if pc, perr := dialPrivate("unix:path=/run/systemd/private"); perr == nil {
+			c, err = pc, nil
+		}
+	}
+	if err != nil {
+		systemErr = err
+		return nil, err
+	}
+	systemClient = c
+	return c, nil
+}
+
+// User returns the shared client for the systemd --user instance of uid,
+// reached through that user's session bus in /run/user/<uid>/bus.
+func User(uid int) (*Client, error) {
+	clientsMu.Lock()
+	defer clientsMu.Unlock()
+	if c, ok := userClients[uid]; ok {
+		if c.conn.alive() {
+			return c, nil
+		}
+		c.Close()
+		delete(userClients, uid)
+	}
+	if err, ok := userErrs[uid]; ok {
+		return nil, err
+	}
+
+	addr := fmt.Sprintf("unix:path=/run/user/%d/bus", uid)
+	if uid == os.Geteuid() {
+		if env := os.Getenv("DBUS_SESSION_BUS_ADDRESS"); env != "" {
+			addr = env + ";" + addr
+		}
+	}
+	c, err := Dial(addr)
+	if err != nil {
+		userErrs[uid] = err
+		return nil, err
+	}
+	userClients[uid] = c
+	return c, nil
+}
+
+// Invalidate drops cached unit properties and remembered connection failures,
+// so a new analysis sees the current ActiveState, MainPID, NRestarts and
+// trigger times. Open connections are kept.
+func Invalidate() {
+	clientsMu.Lock()
+	defer clientsMu.Unlock()
+	systemErr = nil
+	clear(userErrs)
+	if systemClient != nil {
+		systemClient.reset()
+	}
+	for _, c := range userClients {
+		c.reset()
+	}
+}
+
+// Dial connects to a message bus at a D-Bus address and registers with Hello
+func Dial(address string) (*Client, error) {
+	c, err := dial(address, true)
+	if err != nil {
+		return nil, err
+	}
+	return newClient(c), nil
+}
+
+// dialPrivate connects peer-to-peer to systemd's private socket, which speaks
+// D-Bus without a bus daemon and so takes no Hello
+func dialPrivate(address string) (*Client, error) {
+	c, err := dial(address, false)
+	if err != nil {
+		return nil, err
+	}
+	return newClient(c), nil
+}
+
+func newClient(c *conn) *Client {
+	return &Client{
+		conn:  c,
+		props: make(map[string]map[string]any),
+		paths: make(map[string]string),
+		names: make(map[string]string),
+	}
+}
+
+// reset forgets every cached unit
+func (c *Client) reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	clear(c.props)
+	clear(c.paths)
+	clear(c.names)
+}
+
+// Close closes the underlying connection
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+func (c *Client) callManager(member, sig string, args ...any) ([]any, error) {
+	return c.conn.call(busName, managerPath, managerIface, member, sig, args...)
+}
+
+// getAll fetches every property of every interface on an object in one call
+func (c *Client) getAll(path string) (map[string]any, error) {
+	body, err := c.conn.call(busName, path, propertyIface, "GetAll", "s", "")
+	if err != nil {
+		return nil, err
+	}
+	if len(body) == 0 {
+		return nil, fmt.Errorf("empty GetAll reply for %s", path)
+	}
+	props, ok := body[0].(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("unexpected GetAll reply for %s", path)
+	}
+	return props, nil
+}
+
+// UnitByPID returns the unit a process belongs to, caching its properties
+func (c *Client) UnitByPID(pid int) (string, error) {
+	body, err := c.callManager("GetUnitByPID", "u", uint32(pid))
+	if err != nil {
+		return "", err
+	}
+	if len(body) == 0 {
+		return "", fmt.Errorf("empty GetUnitByPID reply for pid %d", pid)
+	}
+	path, _ := body[0].(string)
+
+	// Ancestors usually share a unit; fetch its properties once
+	c.mu.Lock()
+	name, ok := c.names[path]
+	c.mu.Unlock()
+	if ok {
+		return name, nil
+	}
+
+	props, err := c.getAll(path)
+	if err != nil {
+		return "", err
+	}
+	name, _ = props["Id"].(string)
+	if name == "" {
+		return "", fmt.Errorf("unit for pid %d has no Id", pid)
+	}
+	c.store(name, path, props)
+	return name, nil
+}
+
+func (c *Client) store(name, path string, props map[string]any) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.props[name] = props
+	c.paths[name] = path
+	if id, ok := props["Id"].(string); ok && id != "" {
+		c.names[path] = id
+	}
+}
+
+// Properties returns all properties of a unit (Unit, Service, Socket, Timer...
+// interfaces merged), loading the unit like `systemctl show` does.
+func (c *Client) Properties(unit string) (map[string]any, error) {
+	c.mu.Lock()
+	props, ok := c.props[unit]
+	c.mu.Unlock()
+	if ok {
+		return props, nil
+	}
+
+	body, err := c.callManager("LoadUnit", "s", unit)
+	if err != nil {
+		return nil, err
+	}
+	if len(body) == 0 {
+		return nil, fmt.Errorf("empty LoadUnit reply for %s", unit)
+	}
+	path, _ := body[0].(string)
+	props, err = c.getAll(path)
+	if err != nil {
+		return nil, err
+	}
+
+	c.store(unit, path, props)
+	return props, nil
+}
+
+// Property returns a single unit property
+func (c *Client) Property(unit, name string) (any, error) {
+	props, err := c.Properties(unit)
+	if err != nil {
+		return nil, err
+	}
+	v, ok := props[name]
+	if !ok {
+		return nil, fmt.Errorf("unit %s has no property %s", unit, name)
+	}
+	return v, nil
+}
+
+// listUnits returns loaded units matching glob patterns with their object paths
+func (c *Client) listUnits(patterns ...string) (map[string]string, error) {
+	body, err := c.callManager("ListUnitsByPatterns", "asas", []string{}, patterns)
+	if err != nil {
+		// systemd before v230 only has the unfiltered ListUnits
+		if body, err = c.callManager("ListUnits", ""); err != nil {
+			return nil, err
+		}
+	}
+	if len(body) == 0 {
+		return nil, fmt.Errorf("empty ListUnits reply")
+	}
+	units, _ := body[0].([]any)
+
+	out := make(map[string]string)
+	for _, u := range units {
+		// (name, description, load, active, sub, following, path, job id, job type, job path)
+		fields, ok := u.([]any)
+		if !ok || len(fields) < 7 {
+			continue
+		}
+		name, _ := fields[0].(string)
+		path, _ := fields[6].(string)
+		for _, p := range patterns {
+			if ok, _ := filepath.Match(p, name); ok {
+				out[name] = path
+				break
+			}
+		}
+	}
+	return out, nil
+}
+
+// Sockets lists loaded socket units with their listen addresses and triggered units
+func (c *Client) Sockets() ([]Socket, error) {
+	units, err := c.listUnits("*.socket")
+	if err != nil {
+		return nil, err
+	}
+
+	var sockets []Socket
+	for name, path := range units {
+		props, err := c.getAll(path)
+		if err != nil {
+			continue
+		}
+		c.store(name, path, props)
+		sockets = append(sockets, Socket{
+			Unit:     name,
+			Listen:   propPairs(props["Listen"]),
+			Triggers: Strings(props["Triggers"]),
+		})
+	}
+	slices.SortFunc(sockets, func(a, b Socket) int { return strings.Compare(a.Unit, b.Unit) })
+	return sockets, nil
+}
+
+// TriggersOf finds the timer, socket and path units whose Triggers= include
+// unit, for managers that do not expose TriggeredBy.
+func (c *Client) TriggersOf(unit string) ([]string, error) {
+	units, err := c.listUnits("*.timer", "*.socket", "*.path")
+	if err != nil {
+		return nil, err
+	}
+	var out []string
+	for name, path := range units {
+		props, err := c.getAll(path)
+		if err != nil {
+			continue
+		}
+		c.store(name, path, props)
+		if slices.Contains(Strings(props["Triggers"]), unit) {
+			out = append(out, name)
+		}
+	}
+	slices.Sort(out)
+	return out, nil
+}
+
+// Strings converts an "as" property value to []string
+func Strings(v any) []string {
+	items, _ := v.([]any)
+	out := make([]string, 0, len(items))
+	for _, item := range items {
+		if s, ok := item.(string); ok {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// FormatValue renders a property value the way `systemctl show --value`
+// prints simple types. Structured values render as "".
+func FormatValue(v any) string {
+	switch x := v.(type) {
+	case string:
+		return x
+	case bool:
+		if x {
+			return "yes"
+		}
+		return "no"
+	case byte:
+		return strconv.Itoa(int(x))
+	case int16, int32, int64:
+		return fmt.Sprint(x)
+	case uint16, uint32, uint64:
+		return fmt.Sprint(x)
+	case float64:
+		return strconv.FormatFloat(x, 'g', -1, 64)
+	case []any:
+		parts := make([]string, 0, len(x))
+		for _, item := range x {
+			s, ok := item.(string)
+			if !ok {
+				return ""
+			}
+			if strings.ContainsAny(s, " \t\"") {
+				s = strconv.Quote(s)
+			}
+			parts = append(parts, s)
+		}
+		return strings.Join(parts, " ")
+	}
+	return ""
+}
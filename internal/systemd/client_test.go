@@ -0,0 +1,333 @@
+package systemd
+
+import (
+	"bufio"
+	"net"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeBus is a minimal D-Bus peer serving canned replies per method
+type fakeBus struct {
+	t       *testing.T
+	addr    string
+	mu      sync.Mutex
+	calls   []string
+	methods map[string]func(args []any) (string, []any, *Error)
+}
+
+func newFakeBus(t *testing.T) *fakeBus {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "bus")
+	ln, err := net.Listen("unix", path)
+	if err != nil {
+		t.Skipf("unix sockets unavailable: %v", err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	b := &fakeBus{t: t, addr: "unix:path=" + path, methods: map[string]func([]any) (string, []any, *Error){}}
+	b.methods["Hello"] = func([]any) (string, []any, *Error) { return "s", []any{":1.42"}, nil }
+	go func() {
+		for {
+			c, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go b.serve(c)
+		}
+	}()
+	return b
+}
+
+func (b *fakeBus) serve(c net.Conn) {
+	defer c.Close()
+	r := bufio.NewReader(c)
+	if nul, err := r.ReadByte(); err != nil || nul != 0 {
+		return
+	}
+	line, err := r.ReadString('\n')
+	if err != nil || !strings.HasPrefix(line, "AUTH EXTERNAL ") {
+		return
+	}
+	c.Write([]byte("OK 0123456789abcdef0123456789abcdef\r\n"))
+	if line, err = r.ReadString('\n'); err != nil || line != "BEGIN\r\n" {
+		return
+	}
+
+	var serial uint32 = 1000
+	for {
+		m, err := readMessage(r)
+		if err != nil {
+			return
+		}
+		b.mu.Lock()
+		b.calls = append(b.calls, m.member+" "+m.path)
+		handler := b.methods[m.member]
+		b.mu.Unlock()
+
+		serial++
+		fields := []any{[]any{byte(fieldReplySerial), Variant{"u", m.serial}}}
+		var reply []byte
+		switch sig, body, derr := b.reply(handler, m.body); {
+		case derr != nil:
+			fields = append(fields,
+				[]any{byte(fieldErrorName), Variant{"s", derr.Name}},
+				[]any{byte(fieldSignature), Variant{"g", "s"}})
+			reply, err = encodeMessage(msgError, serial, fields, "s", []any{derr.Message})
+		default:
+			if sig != "" {
+				fields = append(fields, []any{byte(fieldSignature), Variant{"g", sig}})
+			}
+			reply, err = encodeMessage(msgMethodReturn, serial, fields, sig, body)
+		}
+		if err != nil {
+			b.t.Errorf("encode reply to %s: %v", m.member, err)
+			return
+		}
+		c.Write(reply)
+	}
+}
+
+func (b *fakeBus) reply(handler func([]any) (string, []any, *Error), args []any) (string, []any, *Error) {
+	if handler == nil {
+		return "", nil, &Error{Name: "org.freedesktop.DBus.Error.UnknownMethod", Message: "no such method"}
+	}
+	return handler(args)
+}
+
+func (b *fakeBus) callCount(prefix string) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	n := 0
+	for _, c := range b.calls {
+		if strings.HasPrefix(c, prefix) {
+			n++
+		}
+	}
+	return n
+}
+
+func nginxProps() map[string]Variant {
+	return map[string]Variant{
+		"Id":           {"s", "nginx.service"},
+		"Description":  {"s", "A high performance web server"},
+		"FragmentPath": {"s", "/lib/systemd/system/nginx.service"},
+		"NRestarts":    {"u", uint32(3)},
+		"MainPID":      {"u", uint32(812)},
+		"Environment":  {"as", []string{"A=1", "B=two words"}},
+		"EnvironmentFiles": {"a(sb)", []any{
+			[]any{"/etc/default/nginx", true},
+		}},
+	}
+}
+
+func TestClientUnitByPIDAndProperties(t *testing.T) {
+	bus := newFakeBus(t)
+	const unitPath = "/org/freedesktop/systemd1/unit/nginx_2eservice"
+	bus.methods["GetUnitByPID"] = func(args []any) (string, []any, *Error) {
+		if args[0] != uint32(812) {
+			return "", nil, &Error{Name: "org.freedesktop.systemd1.NoUnitForPID", Message: "PID not managed"}
+		}
+		return "o", []any{unitPath}, nil
+	}
+	bus.methods["GetAll"] = func(args []any) (string, []any, *Error) {
+		if args[0] != "" {
+			t.Errorf("GetAll interface = %q, want all interfaces", args[0])
+		}
+		return "a{sv}", []any{nginxProps()}, nil
+	}
+	bus.methods["LoadUnit"] = func(args []any) (string, []any, *Error) {
+		return "o", []any{unitPath}, nil
+	}
+
+	c, err := Dial(bus.addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	unit, err := c.UnitByPID(812)
+	if err != nil || unit != "nginx.service" {
+		t.Fatalf("UnitByPID = %q, %v", unit, err)
+	}
+	if _, err := c.UnitByPID(1); err == nil || !strings.Contains(err.Error(), "NoUnitForPID") {
+		t.Fatalf("expected NoUnitForPID error, got %v", err)
+	}
+
+	for prop, want := range map[string]string{
+		"Description":  "A high performance web server",
+		"FragmentPath": "/lib/systemd/system/nginx.service",
+		"NRestarts":    "3",
+		"Environment":  `A=1 "B=two words"`,
+	} {
+		v, err := c.Property("nginx.service", prop)
+		if err != nil {
+			t.Fatalf("Property(%s): %v", prop, err)
+		}
+		if got := FormatValue(v); got != want {
+			t.Errorf("%s = %q, want %q", prop, got, want)
+		}
+	}
+
+	files, _ := c.Property("nginx.service", "EnvironmentFiles")
+	entry := files.([]any)[0].([]any)
+	if entry[0] != "/etc/default/nginx" || entry[1] != true {
+		t.Errorf("EnvironmentFiles = %v", files)
+	}
+
+	// Properties come from the single GetAll made by UnitByPID
+	if n := bus.callCount("GetAll"); n != 1 {
+		t.Errorf("expected 1 GetAll round trip, got %d", n)
+	}
+	if n := bus.callCount("LoadUnit"); n != 0 {
+		t.Errorf("expected cached properties without LoadUnit, got %d calls", n)
+	}
+}
+
+func TestClientSockets(t *testing.T) {
+	bus := newFakeBus(t)
+	bus.methods["ListUnitsByPatterns"] = func(args []any) (string, []any, *Error) {
+		if !slices.Equal(Strings(args[1]), []string{"*.socket"}) {
+			t.Errorf("unexpected patterns %v", args[1])
+		}
+		unit := func(name, path string) []any {
+			return []any{name, "", "loaded", "active", "listening", "", path, uint32(0), "", "/"}
+		}
+		return "a(ssssssouso)", []any{[]any{
+			unit("ssh.socket", "/org/freedesktop/systemd1/unit/ssh_2esocket"),
+			unit("cups.socket", "/org/freedesktop/systemd1/unit/cups_2esocket"),
+		}}, nil
+	}
+	bus.methods["GetAll"] = func(args []any) (string, []any, *Error) {
+		return "a{sv}", []any{map[string]Variant{
+			"Listen":   {"a(ss)", []any{[]any{"Stream", "[::]:22"}, []any{"Stream", "0.0.0.0:22"}}},
+			"Triggers": {"as", []string{"ssh.service"}},
+		}}, nil
+	}
+
+	c, err := Dial(bus.addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	sockets, err := c.Sockets()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sockets) != 2 || sockets[0].Unit != "cups.socket" || sockets[1].Unit != "ssh.socket" {
+		t.Fatalf("unexpected sockets: %+v", sockets)
+	}
+	s := sockets[1]
+	if len(s.Listen) != 2 || s.Listen[1] != (Listen{"Stream", "0.0.0.0:22"}) || !slices.Equal(s.Triggers, []string{"ssh.service"}) {
+		t.Fatalf("unexpected socket: %+v", s)
+	}
+}
+
+func TestClientEmptyReplies(t *testing.T) {
+	bus := newFakeBus(t)
+	empty := func([]any) (string, []any, *Error) { return "", nil, nil }
+	for _, m := range []string{"GetUnitByPID", "LoadUnit", "ListUnitsByPatterns", "ListUnits"} {
+		bus.methods[m] = empty
+	}
+
+	c, err := Dial(bus.addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	if _, err := c.UnitByPID(812); err == nil {
+		t.Error("UnitByPID: expected error for empty reply")
+	}
+	if _, err := c.Properties("nginx.service"); err == nil {
+		t.Error("Properties: expected error for empty reply")
+	}
+	if _, err := c.Sockets(); err == nil {
+		t.Error("Sockets: expected error for empty reply")
+	}
+}
+
+func TestInvalidateRefetchesProperties(t *testing.T) {
+	bus := newFakeBus(t)
+	restarts := uint32(0)
+	bus.methods["LoadUnit"] = func([]any) (string, []any, *Error) {
+		return "o", []any{"/org/freedesktop/systemd1/unit/nginx_2eservice"}, nil
+	}
+	bus.methods["GetAll"] = func([]any) (string, []any, *Error) {
+		restarts++
+		return "a{sv}", []any{map[string]Variant{"Id": {"s", "nginx.service"}, "NRestarts": {"u", restarts}}}, nil
+	}
+
+	c, err := Dial(bus.addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	clientsMu.Lock()
+	systemClient, systemErr = c, nil
+	clientsMu.Unlock()
+	t.Cleanup(func() {
+		clientsMu.Lock()
+		systemClient, systemErr = nil, nil
+		clientsMu.Unlock()
+		c.Close()
+	})
+
+	nrestarts := func() any {
+		sc, err := System()
+		if err != nil {
+			t.Fatal(err)
+		}
+		v, err := sc.Property("nginx.service", "NRestarts")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return v
+	}
+	if v := nrestarts(); v != uint32(1) {
+		t.Fatalf("NRestarts = %v, want 1", v)
+	}
+	if v := nrestarts(); v != uint32(1) {
+		t.Fatalf("NRestarts = %v, want the cached 1", v)
+	}
+	Invalidate()
+	if v := nrestarts(); v != uint32(2) {
+		t.Fatalf("NRestarts after Invalidate = %v, want 2", v)
+	}
+}
+
+func TestBrokenConnection(t *testing.T) {
+	bus := newFakeBus(t)
+	c, err := Dial(bus.addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !c.conn.alive() {
+		t.Fatal("fresh connection reported broken")
+	}
+	c.conn.c.Close()
+	if _, err := c.UnitByPID(1); err == nil {
+		t.Fatal("expected error on closed connection")
+	}
+	if c.conn.alive() {
+		t.Fatal("connection should be marked broken after a failed call")
+	}
+}
+
+func TestDialRejectsMissingSocket(t *testing.T) {
+	if _, err := Dial("unix:path=" + filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Fatal("expected error for missing bus socket")
+	}
+	if _, err := Dial("tcp:host=localhost,port=1"); err == nil {
+		t.Fatal("expected error for unsupported transport")
+	}
+}
+
+func TestUnescapeAddress(t *testing.T) {
+	if got := unescapeAddress("/run/user/1000/bus%2cx"); got != "/run/user/1000/bus,x" {
+		t.Fatalf("got %q", got)
+	}
+}
@@ -0,0 +1,743 @@
+package systemd
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"os"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// This file implements the subset of the D-Bus wire protocol needed to call
+// methods on systemd: SASL EXTERNAL authentication over a unix socket and
+// little-endian marshalling of method calls and replies.
+
+const (
+	msgMethodCall   = 1
+	msgMethodReturn = 2
+	msgError        = 3
+
+	fieldPath        = 1
+	fieldInterface   = 2
+	fieldMember      = 3
+	fieldErrorName   = 4
+	fieldReplySerial = 5
+	fieldDestination = 6
+	fieldSignature   = 8
+
+	// flagNoAutoStart stops the bus from activating systemd1 where it is not running
+	flagNoAutoStart = 0x2
+
+	// callTimeout bounds each round trip so a wedged bus cannot hang witr
+	callTimeout = 2 * time.Second
+)
+
+// Variant is a D-Bus variant with an explicit signature, used when encoding
+type Variant struct {
+	Sig   string
+	Value any
+}
+
+// Error is a D-Bus error reply such as org.freedesktop.systemd1.NoSuchUnit
+type Error struct {
+	Name    string
+	Message string
+}
+
+func (e *Error) Error() string {
+	if e.Message != "" {
+		return e.Name + ": " + e.Message
+	}
+	return e.Name
+}
+
+// conn is an authenticated connection to a bus or to systemd's private socket
+type conn struct {
+	mu     sync.Mutex
+	c      net.Conn
+	r      *bufio.Reader
+	serial uint32
+	broken bool // a read or write failed and the stream may be out of step
+}
+
+// dial connects to the first reachable entry of a D-Bus address such as
+// "unix:path=/run/dbus/system_bus_socket;unix:abstract=/tmp/dbus-x".
+func dial(address string, hello bool) (*conn, error) {
+	var lastErr error
+	for _, entry := range strings.Split(address, ";") {
+		transport, params, ok := strings.Cut(entry, ":")
+		if !ok || transport != "unix" {
+			continue
+		}
+		var path string
+		for _, kv := range strings.Split(params, ",") {
+			k, v, _ := strings.Cut(kv, "=")
+			switch k {
+			case "path":
+				path = unescapeAddress(v)
+			case "abstract":
+				path = "@" + unescapeAddress(v)
+			}
+		}
+		if path == "" {
+			continue
+		}
+		nc, err := net.DialTimeout("unix", path, callTimeout)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		c := &conn{c: nc, r: bufio.NewReader(nc)}
+		if err := c.auth(); err != nil {
+			nc.Close()
+			lastErr = err
+			continue
+		}
+		if hello {
+			if _, err := c.call("org.freedesktop.DBus", "/org/freedesktop/DBus", "org.freedesktop.DBus", "Hello", ""); err != nil {
+				nc.Close()
+				lastErr = err
+				continue
+			}
+		}
+		return c, nil
+	}
+	if lastErr == nil {
+		lastErr = fmt.Errorf("no usable unix transport in D-Bus address %q", address)
+	}
+	return nil, lastErr
+}
+
+// unescapeAddress decodes %xx escapes in D-Bus address values
+func unescapeAddress(s string) string {
+	if !strings.Contains(s, "%") {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '%' && i+2 < len(s) {
+			if v, err := strconv.ParseUint(s[i+1:i+3], 16, 8); err == nil {
+				b.WriteByte(byte(v))
+				i += 2
+				continue
+			}
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+// auth performs SASL EXTERNAL with the effective uid
+func (c *conn) auth() error {
+	c.c.SetDeadline(time.Now().Add(callTimeout))
+	defer c.c.SetDeadline(time.Time{})
+
+	uid := hex.EncodeToString([]byte(strconv.Itoa(os.Geteuid())))
+	if _, err := io.WriteString(c.c, "\x00AUTH EXTERNAL "+uid+"\r\n"); err != nil {
+		return err
+	}
+	line, err := c.r.ReadString('\n')
+	if err != nil {
+		return err
+	}
+	if !strings.HasPrefix(line, "OK ") {
+		return fmt.Errorf("D-Bus authentication rejected: %s", strings.TrimSpace(line))
+	}
+	_, err = io.WriteString(c.c, "BEGIN\r\n")
+	return err
+}
+
+func (c *conn) Close() error {
+	return c.c.Close()
+}
+
+// alive reports whether the connection can still carry calls
+func (c *conn) alive() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return !c.broken
+}
+
+// call sends a method call and waits for its reply, skipping signals and
+// unrelated messages. sig describes args; the reply body is decoded by its
+// own signature.
+func (c *conn) call(dest, path, iface, member, sig string, args ...any) ([]any, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.serial++
+	serial := c.serial
+	fields := []any{
+		[]any{byte(fieldPath), Variant{"o", path}},
+		[]any{byte(fieldInterface), Variant{"s", iface}},
+		[]any{byte(fieldMember), Variant{"s", member}},
+	}
+	if dest != "" {
+		fields = append(fields, []any{byte(fieldDestination), Variant{"s", dest}})
+	}
+	if sig != "" {
+		fields = append(fields, []any{byte(fieldSignature), Variant{"g", sig}})
+	}
+	msg, err := encodeMessage(msgMethodCall, serial, fields, sig, args)
+	if err != nil {
+		return nil, err
+	}
+
+	c.c.SetDeadline(time.Now().Add(callTimeout))
+	defer c.c.SetDeadline(time.Time{})
+	if _, err := c.c.Write(msg); err != nil {
+		c.broken = true
+		return nil, err
+	}
+
+	for {
+		m, err := readMessage(c.r)
+		if err != nil {
+			c.broken = true
+			return nil, err
+		}
+		if m.replySerial != serial {
+			continue
+		}
+		switch m.typ {
+		case msgMethodReturn:
+			return m.body, nil
+		case msgError:
+			e := &Error{Name: m.errorName}
+			if len(m.body) > 0 {
+				e.Message, _ = m.body[0].(string)
+			}
+			return nil, e
+		}
+	}
+}
+
+// encodeMessage builds a complete little-endian message
+func encodeMessage(typ byte, serial uint32, fields []any, sig string, args []any) ([]byte, error) {
+	body := &encoder{}
+	if err := body.encodeAll(sig, args); err != nil {
+		return nil, err
+	}
+
+	hdr := &encoder{}
+	var flags byte
+	if typ == msgMethodCall {
+		flags = flagNoAutoStart
+	}
+	hdr.buf = append(hdr.buf, 'l', typ, flags, 1)
+	hdr.uint32(uint32(len(body.buf)))
+	hdr.uint32(serial)
+	if err := hdr.encode("a(yv)", fields); err != nil {
+		return nil, err
+	}
+	hdr.align(8)
+	return append(hdr.buf, body.buf...), nil
+}
+
+type message struct {
+	typ         byte
+	serial      uint32
+	replySerial uint32
+	path        string
+	iface       string
+	member      string
+	errorName   string
+	destination string
+	sig         string
+	body        []any
+}
+
+// readMessage reads and decodes one message
+func readMessage(r io.Reader) (*message, error) {
+	fixed := make([]byte, 16)
+	if _, err := io.ReadFull(r, fixed); err != nil {
+		return nil, err
+	}
+	var order binary.ByteOrder
+	switch fixed[0] {
+	case 'l':
+		order = binary.LittleEndian
+	case 'B':
+		order = binary.BigEndian
+	default:
+		return nil, fmt.Errorf("invalid D-Bus endianness marker %q", fixed[0])
+	}
+	bodyLen := order.Uint32(fixed[4:8])
+	fieldsLen := order.Uint32(fixed[12:16])
+	if bodyLen > 64<<20 || fieldsLen > 64<<20 {
+		return nil, errors.New("D-Bus message too large")
+	}
+
+	headerLen := 16 + int(fieldsLen)
+	padded := (headerLen + 7) &^ 7
+	rest := make([]byte, padded-16+int(bodyLen))
+	if _, err := io.ReadFull(r, rest); err != nil {
+		return nil, err
+	}
+	full := append(fixed, rest...)
+
+	m := &message{typ: fixed[1], serial: order.Uint32(fixed[8:12])}
+	hd := &decoder{buf: full[:headerLen], pos: 12, order: order}
+	raw, err := hd.decode("a(yv)")
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range raw.([]any) {
+		pair := f.([]any)
+		code, _ := pair[0].(byte)
+		switch code {
+		case fieldPath:
+			m.path, _ = pair[1].(string)
+		case fieldInterface:
+			m.iface, _ = pair[1].(string)
+		case fieldMember:
+			m.member, _ = pair[1].(string)
+		case fieldErrorName:
+			m.errorName, _ = pair[1].(string)
+		case fieldReplySerial:
+			m.replySerial, _ = pair[1].(uint32)
+		case fieldDestination:
+			m.destination, _ = pair[1].(string)
+		case fieldSignature:
+			m.sig, _ = pair[1].(string)
+		}
+	}
+
+	bd := &decoder{buf: full[padded:], order: order}
+	for sig := m.sig; sig != ""; {
+		one, rest, err := nextType(sig)
+		if err != nil {
+			return nil, err
+		}
+		v, err := bd.decode(one)
+		if err != nil {
+			return nil, err
+		}
+		m.body = append(m.body, v)
+		sig = rest
+	}
+	return m, nil
+}
+
+// nextType splits the first complete type off a signature
+func nextType(sig string) (string, string, error) {
+	if sig == "" {
+		return "", "", errors.New("empty signature")
+	}
+	switch sig[0] {
+	case 'a':
+		elem, rest, err := nextType(sig[1:])
+		if err != nil {
+			return "", "", err
+		}
+		return "a" + elem, rest, nil
+	case '(', '{':
+		closer := byte(')')
+		if sig[0] == '{' {
+			closer = '}'
+		}
+		depth := 0
+		for i := 0; i < len(sig); i++ {
+			switch sig[i] {
+			case '(', '{':
+				depth++
+			case ')', '}':
+				depth--
+				if depth == 0 {
+					if sig[i] != closer {
+						return "", "", fmt.Errorf("mismatched signature %q", sig)
+					}
+					return sig[:i+1], sig[i+1:], nil
+				}
+			}
+		}
+		return "", "", fmt.Errorf("unterminated signature %q", sig)
+	default:
+		return sig[:1], sig[1:], nil
+	}
+}
+
+func alignment(t byte) int {
+	switch t {
+	case 'y', 'g', 'v':
+		return 1
+	case 'n', 'q':
+		return 2
+	case 'x', 't', 'd', '(', '{':
+		return 8
+	default:
+		return 4
+	}
+}
+
+type encoder struct {
+	buf []byte
+}
+
+func (e *encoder) align(n int) {
+	for len(e.buf)%n != 0 {
+		e.buf = append(e.buf, 0)
+	}
+}
+
+func (e *encoder) uint32(v uint32) {
+	e.align(4)
+	e.buf = binary.LittleEndian.AppendUint32(e.buf, v)
+}
+
+func (e *encoder) encodeAll(sig string, args []any) error {
+	for i := 0; sig != ""; i++ {
+		one, rest, err := nextType(sig)
+		if err != nil {
+			return err
+		}
+		if i >= len(args) {
+			return fmt.Errorf("missing argument for signature %q", one)
+		}
+		if err := e.encode(one, args[i]); err != nil {
+			return err
+		}
+		sig = rest
+	}
+	return nil
+}
+
+// encode marshals v as the single complete type sig
+func (e *encoder) encode(sig string, v any) error {
+	switch sig[0] {
+	case 'y':
+		b, ok := v.(byte)
+		if !ok {
+			return typeError(sig, v)
+		}
+		e.buf = append(e.buf, b)
+	case 'b':
+		b, ok := v.(bool)
+		if !ok {
+			return typeError(sig, v)
+		}
+		var n uint32
+		if b {
+			n = 1
+		}
+		e.uint32(n)
+	case 'n', 'q':
+		rv := reflect.ValueOf(v)
+		if !rv.CanInt() && !rv.CanUint() {
+			return typeError(sig, v)
+		}
+		e.align(2)
+		e.buf = binary.LittleEndian.AppendUint16(e.buf, uint16(intOf(rv)))
+	case 'i', 'u', 'h':
+		rv := reflect.ValueOf(v)
+		if !rv.CanInt() && !rv.CanUint() {
+			return typeError(sig, v)
+		}
+		e.uint32(uint32(intOf(rv)))
+	case 'x', 't':
+		rv := reflect.ValueOf(v)
+		if !rv.CanInt() && !rv.CanUint() {
+			return typeError(sig, v)
+		}
+		e.align(8)
+		e.buf = binary.LittleEndian.AppendUint64(e.buf, intOf(rv))
+	case 's', 'o':
+		s, ok := v.(string)
+		if !ok {
+			return typeError(sig, v)
+		}
+		e.uint32(uint32(len(s)))
+		e.buf = append(e.buf, s...)
+		e.buf = append(e.buf, 0)
+	case 'g':
+		s, ok := v.(string)
+		if !ok {
+			return typeError(sig, v)
+		}
+		e.buf = append(e.buf, byte(len(s)))
+		e.buf = append(e.buf, s...)
+		e.buf = append(e.buf, 0)
+	case 'v':
+		vv, ok := v.(Variant)
+		if !ok {
+			return typeError(sig, v)
+		}
+		if err := e.encode("g", vv.Sig); err != nil {
+			return err
+		}
+		return e.encode(vv.Sig, vv.Value)
+	case '(':
+		fields, ok := v.([]any)
+		if !ok {
+			return typeError(sig, v)
+		}
+		e.align(8)
+		return e.encodeAll(sig[1:len(sig)-1], fields)
+	case 'a':
+		return e.encodeArray(sig[1:], v)
+	default:
+		return fmt.Errorf("unsupported D-Bus type %q", sig)
+	}
+	return nil
+}
+
+func (e *encoder) encodeArray(elem string, v any) error {
+	e.uint32(0)
+	lenPos := len(e.buf) - 4
+	e.align(alignment(elem[0]))
+	start := len(e.buf)
+
+	rv := reflect.ValueOf(v)
+	switch {
+	case elem[0] == '{' && rv.Kind() == reflect.Map:
+		keySig, valSig, err := nextType(elem[1 : len(elem)-1])
+		if err != nil {
+			return err
+		}
+		keys := rv.MapKeys()
+		slices.SortFunc(keys, func(a, b reflect.Value) int {
+			return strings.Compare(fmt.Sprint(a.Interface()), fmt.Sprint(b.Interface()))
+		})
+		for _, k := range keys {
+			e.align(8)
+			if err := e.encode(keySig, k.Interface()); err != nil {
+				return err
+			}
+			if err := e.encode(valSig, rv.MapIndex(k).Interface()); err != nil {
+				return err
+			}
+		}
+	case rv.Kind() == reflect.Slice:
+		for i := range rv.Len() {
+			if err := e.encode(elem, rv.Index(i).Interface()); err != nil {
+				return err
+			}
+		}
+	default:
+		return typeError("a"+elem, v)
+	}
+
+	binary.LittleEndian.PutUint32(e.buf[lenPos:], uint32(len(e.buf)-start))
+	return nil
+}
+
+func intOf(rv reflect.Value) uint64 {
+	if rv.CanInt() {
+		return uint64(rv.Int())
+	}
+	return rv.Uint()
+}
+
+func typeError(sig string, v any) error {
+	return fmt.Errorf("cannot encode %T as D-Bus type %q", v, sig)
+}
+
+type decoder struct {
+	buf   []byte
+	pos   int
+	order binary.ByteOrder
+}
+
+func (d *decoder) align(n int) error {
+	p := (d.pos + n - 1) &^ (n - 1)
+	if p > len(d.buf) {
+		return io.ErrUnexpectedEOF
+	}
+	d.pos = p
+	return nil
+}
+
+func (d *decoder) take(n int) ([]byte, error) {
+	if n < 0 || d.pos+n > len(d.buf) {
+		return nil, io.ErrUnexpectedEOF
+	}
+	b := d.buf[d.pos : d.pos+n]
+	d.pos += n
+	return b, nil
+}
+
+func (d *decoder) fixed(align, size int) ([]byte, error) {
+	if err := d.align(align); err != nil {
+		return nil, err
+	}
+	return d.take(size)
+}
+
+// decode unmarshals one complete type. Arrays and structs become []any,
+// dictionaries with string keys map[string]any, other dictionaries
+// map[any]any, and variants their contained value.
+func (d *decoder) decode(sig string) (any, error) {
+	switch sig[0] {
+	case 'y':
+		b, err := d.take(1)
+		if err != nil {
+			return nil, err
+		}
+		return b[0], nil
+	case 'b':
+		b, err := d.fixed(4, 4)
+		if err != nil {
+			return nil, err
+		}
+		return d.order.Uint32(b) != 0, nil
+	case 'n':
+		b, err := d.fixed(2, 2)
+		if err != nil {
+			return nil, err
+		}
+		return int16(d.order.Uint16(b)), nil
+	case 'q':
+		b, err := d.fixed(2, 2)
+		if err != nil {
+			return nil, err
+		}
+		return d.order.Uint16(b), nil
+	case 'i':
+		b, err := d.fixed(4, 4)
+		if err != nil {
+			return nil, err
+		}
+		return int32(d.order.Uint32(b)), nil
+	case 'u', 'h':
+		b, err := d.fixed(4, 4)
+		if err != nil {
+			return nil, err
+		}
+		return d.order.Uint32(b), nil
+	case 'x':
+		b, err := d.fixed(8, 8)
+		if err != nil {
+			return nil, err
+		}
+		return int64(d.order.Uint64(b)), nil
+	case 't':
+		b, err := d.fixed(8, 8)
+		if err != nil {
+			return nil, err
+		}
+		return d.order.Uint64(b), nil
+	case 'd':
+		b, err := d.fixed(8, 8)
+		if err != nil {
+			return nil, err
+		}
+		return math.Float64frombits(d.order.Uint64(b)), nil
+	case 's', 'o':
+		b, err := d.fixed(4, 4)
+		if err != nil {
+			return nil, err
+		}
+		s, err := d.take(int(d.order.Uint32(b)) + 1)
+		if err != nil {
+			return nil, err
+		}
+		return string(s[:len(s)-1]), nil
+	case 'g':
+		b, err := d.take(1)
+		if err != nil {
+			return nil, err
+		}
+		s, err := d.take(int(b[0]) + 1)
+		if err != nil {
+			return nil, err
+		}
+		return string(s[:len(s)-1]), nil
+	case 'v':
+		inner, err := d.decode("g")
+		if err != nil {
+			return nil, err
+		}
+		one, rest, err := nextType(inner.(string))
+		if err != nil || rest != "" {
+			return nil, fmt.Errorf("invalid variant signature %q", inner)
+		}
+		return d.decode(one)
+	case '(':
+		if err := d.align(8); err != nil {
+			return nil, err
+		}
+		var fields []any
+		for inner := sig[1 : len(sig)-1]; inner != ""; {
+			one, rest, err := nextType(inner)
+			if err != nil {
+				return nil, err
+			}
+			v, err := d.decode(one)
+			if err != nil {
+				return nil, err
+			}
+			fields = append(fields, v)
+			inner = rest
+		}
+		return fields, nil
+	case 'a':
+		return d.decodeArray(sig[1:])
+	}
+	return nil, fmt.Errorf("unsupported D-Bus type %q", sig)
+}
+
+func (d *decoder) decodeArray(elem string) (any, error) {
+	b, err := d.fixed(4, 4)
+	if err != nil {
+		return nil, err
+	}
+	n := int(d.order.Uint32(b))
+	if err := d.align(alignment(elem[0])); err != nil {
+		return nil, err
+	}
+	end := d.pos + n
+	if n < 0 || end > len(d.buf) {
+		return nil, io.ErrUnexpectedEOF
+	}
+
+	if elem[0] == '{' {
+		keySig, valSig, err := nextType(elem[1 : len(elem)-1])
+		if err != nil {
+			return nil, err
+		}
+		strMap := map[string]any{}
+		anyMap := map[any]any{}
+		for d.pos < end {
+			if err := d.align(8); err != nil {
+				return nil, err
+			}
+			k, err := d.decode(keySig)
+			if err != nil {
+				return nil, err
+			}
+			v, err := d.decode(valSig)
+			if err != nil {
+				return nil, err
+			}
+			if s, ok := k.(string); ok {
+				strMap[s] = v
+			} else {
+				anyMap[k] = v
+			}
+		}
+		if keySig == "s" || keySig == "o" || keySig == "g" {
+			return strMap, nil
+		}
+		return anyMap, nil
+	}
+
+	items := []any{}
+	for d.pos < end {
+		v, err := d.decode(elem)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, v)
+	}
+	return items, nil
+}
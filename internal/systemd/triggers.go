@@ -0,0 +1,368 @@
+package systemd
+
+import (
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TriggerProperties are the properties read from a timer, socket or path unit
+var TriggerProperties = []string{
+	"Id", "TimersCalendar", "TimersMonotonic", "LastTriggerUSec",
+	"NextElapseUSecRealtime", "Listen", "Paths", "Triggers",
+}
+
+// Trigger is a timer, socket or path unit that activates a service
+type Trigger struct {
+	Unit        string
+	Kind        string   // timer, socket or path
+	Schedule    []string // OnCalendar= specs and monotonic timers, as configured
+	LastTrigger time.Time
+	NextElapse  time.Time
+	Listen      []Listen
+	Paths       []Listen // Type is PathExists, PathChanged, ...; Address the path
+	Triggers    []string
+}
+
+// showTimeLayout is how systemctl prints timestamps
+const showTimeLayout = "Mon 2006-01-02 15:04:05 MST"
+
+// TriggerFromProperties builds a Trigger from unit properties, which are either
+// typed D-Bus values or the text printed by `systemctl show`.
+func TriggerFromProperties(unit string, props map[string]any) Trigger {
+	t := Trigger{Unit: unit, Kind: strings.TrimPrefix(path.Ext(unit), ".")}
+
+	switch v := props["TimersCalendar"].(type) {
+	case []any: // a(sst): base, spec, next elapse
+		for _, e := range v {
+			if f, ok := e.([]any); ok && len(f) >= 2 {
+				if spec, ok := f[1].(string); ok {
+					t.Schedule = append(t.Schedule, spec)
+				}
+			}
+		}
+	case string: // { OnCalendar=*-*-* 03:00:00 ; next_elapse=... }
+		for _, entry := range showEntries(v) {
+			if spec, ok := strings.CutPrefix(entry["_first"], "OnCalendar="); ok {
+				t.Schedule = append(t.Schedule, spec)
+			}
+		}
+	}
+
+	switch v := props["TimersMonotonic"].(type) {
+	case []any: // a(stt): base, value µs, next elapse
+		for _, e := range v {
+			if f, ok := e.([]any); ok && len(f) >= 2 {
+				base, _ := f[0].(string)
+				usec, _ := f[1].(uint64)
+				t.Schedule = append(t.Schedule, monotonicSpec(base, formatSpan(time.Duration(usec)*time.Microsecond)))
+			}
+		}
+	case string: // { OnUnitActiveUSec=1d ; next_elapse=... }
+		for _, entry := range showEntries(v) {
+			if base, value, ok := strings.Cut(entry["_first"], "="); ok {
+				t.Schedule = append(t.Schedule, monotonicSpec(base, value))
+			}
+		}
+	}
+
+	t.LastTrigger = propTime(props["LastTriggerUSec"])
+	t.NextElapse = propTime(props["NextElapseUSecRealtime"])
+	t.Listen = propPairs(props["Listen"])
+	t.Paths = propPairs(props["Paths"])
+	t.Triggers = propList(props["Triggers"])
+	return t
+}
+
+// monotonicSpec renders "OnUnitActiveUSec", "1d" as "OnUnitActiveSec=1d"
+func monotonicSpec(base, value string) string {
+	return strings.TrimSuffix(base, "USec") + "Sec=" + value
+}
+
+// formatSpan renders a duration in systemd's style: "1d", "15min", "2h 30min"
+func formatSpan(d time.Duration) string {
+	if d <= 0 {
+		return "0"
+	}
+	units := []struct {
+		name string
+		size time.Duration
+	}{
+		{"d", 24 * time.Hour}, {"h", time.Hour}, {"min", time.Minute}, {"s", time.Second}, {"ms", time.Millisecond},
+	}
+	var parts []string
+	for _, u := range units {
+		if n := d / u.size; n > 0 {
+			parts = append(parts, strconv.FormatInt(int64(n), 10)+u.name)
+			d -= n * u.size
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// showEntries splits "{ a=1 ; b=2 } { a=3 ; b=4 }" into maps; "_first" holds
+// the first assignment of each entry.
+func showEntries(s string) []map[string]string {
+	var entries []map[string]string
+	for _, chunk := range strings.Split(s, "}") {
+		chunk = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(chunk), "{"))
+		if chunk == "" {
+			continue
+		}
+		entry := map[string]string{}
+		for i, part := range strings.Split(chunk, " ; ") {
+			part = strings.TrimSpace(part)
+			if i == 0 {
+				entry["_first"] = part
+			}
+			if k, v, ok := strings.Cut(part, "="); ok {
+				entry[k] = v
+			}
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// propTime converts a realtime µs timestamp or systemctl's text form
+func propTime(v any) time.Time {
+	switch x := v.(type) {
+	case uint64:
+		if x == 0 || x == ^uint64(0) {
+			return time.Time{}
+		}
+		return time.UnixMicro(int64(x))
+	case string:
+		if t, err := time.Parse(showTimeLayout, x); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// propPairs reads a(ss) of (type, address) or systemctl's "address (type)" lines
+func propPairs(v any) []Listen {
+	var out []Listen
+	switch x := v.(type) {
+	case []any:
+		for _, e := range x {
+			if f, ok := e.([]any); ok && len(f) == 2 {
+				typ, _ := f[0].(string)
+				addr, _ := f[1].(string)
+				out = append(out, Listen{Type: typ, Address: addr})
+			}
+		}
+	case string:
+		for line := range strings.Lines(x) {
+			line = strings.TrimSpace(line)
+			if i := strings.LastIndex(line, " ("); i > 0 && strings.HasSuffix(line, ")") {
+				out = append(out, Listen{Type: line[i+2 : len(line)-1], Address: line[:i]})
+			}
+		}
+	}
+	return out
+}
+
+func propList(v any) []string {
+	switch x := v.(type) {
+	case []any:
+		return Strings(x)
+	case string:
+		return strings.Fields(x)
+	}
+	return nil
+}
+
+// Activates returns the service a socket unit starts: its Triggers=, or the
+// same-named .service by default
+func (s Socket) Activates() string {
+	if len(s.Triggers) > 0 {
+		return s.Triggers[0]
+	}
+	return strings.TrimSuffix(s.Unit, ".socket") + ".service"
+}
+
+// ListensOnPort reports whether any stream or datagram address ends in :port
+func (s Socket) ListensOnPort(port int) bool {
+	suffix := ":" + strconv.Itoa(port)
+	for _, l := range s.Listen {
+		if strings.HasSuffix(l.Address, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseListSockets parses `systemctl list-sockets --no-legend --full` rows of
+// "LISTEN UNIT ACTIVATES..." into sockets, merging rows of the same unit.
+func ParseListSockets(out string) []Socket {
+	var sockets []Socket
+	index := map[string]int{}
+	for line := range strings.Lines(out) {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		i, ok := index[fields[1]]
+		if !ok {
+			i = len(sockets)
+			index[fields[1]] = i
+			sockets = append(sockets, Socket{Unit: fields[1], Triggers: fields[2:]})
+		}
+		sockets[i].Listen = append(sockets[i].Listen, Listen{Address: fields[0]})
+	}
+	return sockets
+}
+
+// Summary describes the trigger in a few words: "daily at 03:00, last ran 2h
+// ago", "listening on [::]:22" or "watching /etc/foo".
+func (t Trigger) Summary(now time.Time) string {
+	var parts []string
+	switch t.Kind {
+	case "timer":
+		for _, spec := range t.Schedule {
+			parts = append(parts, HumanSchedule(spec))
+		}
+		if !t.LastTrigger.IsZero() {
+			parts = append(parts, "last ran "+ago(now.Sub(t.LastTrigger)))
+		}
+	case "socket":
+		var addrs []string
+		for _, l := range t.Listen {
+			addrs = append(addrs, l.Address)
+		}
+		if len(addrs) > 0 {
+			parts = append(parts, "listening on "+strings.Join(addrs, ", "))
+		}
+	case "path":
+		var paths []string
+		for _, p := range t.Paths {
+			paths = append(paths, p.Address)
+		}
+		if len(paths) > 0 {
+			parts = append(parts, "watching "+strings.Join(paths, ", "))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Details lists the trigger's configuration, one item per line
+func (t Trigger) Details() []string {
+	var lines []string
+	for _, spec := range t.Schedule {
+		if strings.Contains(spec, "Sec=") {
+			lines = append(lines, spec)
+		} else {
+			lines = append(lines, "OnCalendar="+spec)
+		}
+	}
+	var times []string
+	if !t.LastTrigger.IsZero() {
+		times = append(times, "last "+t.LastTrigger.Local().Format("2006-01-02 15:04"))
+	}
+	if !t.NextElapse.IsZero() {
+		times = append(times, "next "+t.NextElapse.Local().Format("2006-01-02 15:04"))
+	}
+	if len(times) > 0 {
+		lines = append(lines, strings.Join(times, ", "))
+	}
+	for _, l := range t.Listen {
+		lines = append(lines, fmt.Sprintf("Listen%s=%s", l.Type, l.Address))
+	}
+	for _, p := range t.Paths {
+		lines = append(lines, p.Type+"="+p.Address)
+	}
+	return lines
+}
+
+var weekdays = map[string]bool{"Mon": true, "Tue": true, "Wed": true, "Thu": true, "Fri": true, "Sat": true, "Sun": true}
+
+// HumanSchedule renders a normalized OnCalendar= spec in words where it has a
+// common shape ("*-*-* 03:00:00" → "daily at 03:00"), otherwise unchanged.
+func HumanSchedule(spec string) string {
+	switch spec {
+	case "minutely", "hourly", "daily", "weekly", "monthly", "yearly", "annually", "quarterly", "semiannually":
+		return spec
+	}
+	if strings.Contains(spec, "Sec=") {
+		base, value, _ := strings.Cut(spec, "=")
+		switch base {
+		case "OnUnitActiveSec", "OnUnitInactiveSec":
+			return "every " + value
+		case "OnBootSec", "OnStartupSec":
+			return value + " after boot"
+		case "OnActiveSec":
+			return value + " after activation"
+		}
+		return spec
+	}
+
+	fields := strings.Fields(spec)
+	days := ""
+	if len(fields) == 3 {
+		days, fields = fields[0], fields[1:]
+	}
+	if len(fields) != 2 || (days != "" && !isWeekdaySpec(days)) {
+		return spec
+	}
+	date, clock := fields[0], fields[1]
+	hms := strings.Split(clock, ":")
+	if len(hms) != 3 {
+		return spec
+	}
+	h, m, s := hms[0], hms[1], hms[2]
+	if strings.HasPrefix(s, "00") {
+		s = ""
+	}
+
+	at := ""
+	switch {
+	case h == "*" && m == "*" && s == "":
+		return "every minute"
+	case h == "*" && m == "00" && s == "":
+		at = "hourly"
+	case h == "*" && s == "":
+		at = "hourly at :" + m
+	case !strings.ContainsAny(h+m, "*,/.") && s == "":
+		at = "at " + h + ":" + m
+	default:
+		return spec
+	}
+
+	switch {
+	case date == "*-*-*" && days == "" && h == "*":
+		return at
+	case date == "*-*-*" && days == "":
+		return "daily " + at
+	case date == "*-*-*":
+		return days + " " + at
+	case strings.HasPrefix(date, "*-*-") && days == "":
+		return "monthly on day " + strings.TrimPrefix(date, "*-*-") + " " + at
+	}
+	return spec
+}
+
+func isWeekdaySpec(s string) bool {
+	for _, part := range strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == '.' }) {
+		if !weekdays[part] {
+			return false
+		}
+	}
+	return true
+}
+
+// ago renders a duration as "2h ago", "5m ago" or "3d ago"
+func ago(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d.Minutes()))
+	case d < 48*time.Hour:
+		return fmt.Sprintf("%dh ago", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd ago", int(d.Hours()/24))
+	}
+}
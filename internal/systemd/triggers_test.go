@@ -0,0 +1,108 @@
+package systemd
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestHumanSchedule(t *testing.T) {
+	cases := map[string]string{
+		"*-*-* 03:00:00":           "daily at 03:00",
+		"*-*-* *:00:00":            "hourly",
+		"*-*-* *:15:00":            "hourly at :15",
+		"*-*-* *:*:00":             "every minute",
+		"Mon *-*-* 00:00:00":       "Mon at 00:00",
+		"Mon..Fri *-*-* 09:30:00":  "Mon..Fri at 09:30",
+		"*-*-01 04:00:00":          "monthly on day 01 at 04:00",
+		"weekly":                   "weekly",
+		"OnUnitActiveSec=1d":       "every 1d",
+		"OnBootSec=15min":          "15min after boot",
+		"*-*-* 00/6:00:00":         "*-*-* 00/6:00:00",
+		"2026-01-01 00:00:00":      "2026-01-01 00:00:00",
+		"Sat,Sun *-*-* 10:00:00":   "Sat,Sun at 10:00",
+		"Foo *-*-* 10:00:00":       "Foo *-*-* 10:00:00",
+		"*-*-* 03:00:00 UTC extra": "*-*-* 03:00:00 UTC extra",
+	}
+	for spec, want := range cases {
+		if got := HumanSchedule(spec); got != want {
+			t.Errorf("HumanSchedule(%q) = %q, want %q", spec, got, want)
+		}
+	}
+}
+
+func TestTriggerFromProperties(t *testing.T) {
+	last := time.Date(2026, 10, 19, 3, 0, 0, 0, time.UTC)
+	now := last.Add(2*time.Hour + 10*time.Minute)
+
+	typed := TriggerFromProperties("backup.timer", map[string]any{
+		"TimersCalendar":  []any{[]any{"OnCalendar", "*-*-* 03:00:00", uint64(0)}},
+		"TimersMonotonic": []any{[]any{"OnUnitActiveUSec", uint64(90 * time.Minute / time.Microsecond), uint64(0)}},
+		"LastTriggerUSec": uint64(last.UnixMicro()),
+		"Triggers":        []any{"backup.service"},
+	})
+	if want := []string{"*-*-* 03:00:00", "OnUnitActiveSec=1h 30min"}; !slices.Equal(typed.Schedule, want) {
+		t.Errorf("Schedule = %q, want %q", typed.Schedule, want)
+	}
+	if got, want := typed.Summary(now), "daily at 03:00, every 1h 30min, last ran 2h ago"; got != want {
+		t.Errorf("Summary = %q, want %q", got, want)
+	}
+
+	text := TriggerFromProperties("backup.timer", map[string]any{
+		"TimersCalendar":  "{ OnCalendar=*-*-* 03:00:00 ; next_elapse=Tue 2026-10-20 03:00:00 UTC }",
+		"LastTriggerUSec": "Mon 2026-10-19 03:00:00 UTC",
+		"Triggers":        "backup.service",
+	})
+	if got, want := text.Summary(now), "daily at 03:00, last ran 2h ago"; got != want {
+		t.Errorf("Summary = %q, want %q", got, want)
+	}
+	if !slices.Equal(text.Triggers, []string{"backup.service"}) {
+		t.Errorf("Triggers = %q", text.Triggers)
+	}
+
+	sock := TriggerFromProperties("sshd.socket", map[string]any{
+		"Listen": "[::]:22 (Stream)",
+	})
+	if got, want := sock.Summary(now), "listening on [::]:22"; got != want {
+		t.Errorf("Summary = %q, want %q", got, want)
+	}
+	if got := sock.Details(); !slices.Equal(got, []string{"ListenStream=[::]:22"}) {
+		t.Errorf("Details = %q", got)
+	}
+
+	watch := TriggerFromProperties("cups.path", map[string]any{
+		"Paths": []any{[]any{"PathExistsGlob", "/var/spool/cups/d*"}},
+	})
+	if got, want := watch.Summary(now), "watching /var/spool/cups/d*"; got != want {
+		t.Errorf("Summary = %q, want %q", got, want)
+	}
+}
+
+func TestParseListSockets(t *testing.T) {
+	out := `/run/dbus/system_bus_socket dbus.socket      dbus.service
+[::]:22                     sshd.socket      sshd.service
+0.0.0.0:631                 cups.socket
+127.0.0.1:631               cups.socket
+`
+	sockets := ParseListSockets(out)
+	if len(sockets) != 3 {
+		t.Fatalf("got %d sockets, want 3: %+v", len(sockets), sockets)
+	}
+	var byPort = func(port int) string {
+		for _, s := range sockets {
+			if s.ListensOnPort(port) {
+				return s.Activates()
+			}
+		}
+		return ""
+	}
+	if got := byPort(22); got != "sshd.service" {
+		t.Errorf("port 22 → %q", got)
+	}
+	if got := byPort(631); got != "cups.service" {
+		t.Errorf("port 631 → %q", got)
+	}
+	if got := byPort(6310); got != "" {
+		t.Errorf("port 6310 → %q", got)
+	}
+}
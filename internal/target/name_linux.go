@@ -11,6 +11,7 @@ import (
 	"strings"
 
 	procpkg "github.com/pranshuparmar/witr/internal/proc"
+	"github.com/pranshuparmar/witr/internal/systemd"
 )
 
 func ResolveName(name string, exact bool) ([]int, error) {
@@ -123,6 +124,17 @@ func resolveSystemdServiceMainPID(name string) (int, error) {
 	if !strings.HasSuffix(svcName, ".service") {
 		svcName += ".service"
 	}
+	if c, err := systemd.System(); err == nil {
+		v, err := c.Property(svcName, "MainPID")
+		if err != nil {
+			return 0, err
+		}
+		if pid, ok := v.(uint32); ok && pid != 0 {
+			return int(pid), nil
+		}
+		return 0, fmt.Errorf("service %q not running", svcName)
+	}
+
 	out, err := exec.Command("systemctl", "show", "-p", "MainPID", "--value", "--", svcName).Output()
 	if err != nil {
 		return 0, err
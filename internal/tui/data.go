@@ -33,6 +33,7 @@ func (m MainModel) refreshProcesses() tea.Cmd {
 			if p.PPID == selfPID && (p.Command == "ps" || strings.HasPrefix(p.Command, "ps ")) {
 				continue
 			}
+			p.Cmdline = m.redactor.Cmdline(p.Cmdline)
 			filteredProcs = append(filteredProcs, p)
 		}
 		return filteredProcs
@@ -55,12 +56,14 @@ func (m MainModel) fetchTree(p model.Process) tea.Cmd {
 			PID:     p.PID,
 			Verbose: false,
 			Tree:    true,
+			Depth:   1,
 		})
 		if err != nil {
 			return treeMsg(model.Result{
 				Process: p,
 			})
 		}
+		m.redactor.Result(&res)
 		return treeMsg(res)
 	}
 }
@@ -71,10 +74,12 @@ func (m MainModel) fetchProcessDetail(pid int) tea.Cmd {
 			PID:     pid,
 			Verbose: true,
 			Tree:    true,
+			Depth:   1,
 		})
 		if err != nil {
 			return err
 		}
+		m.redactor.Result(&res)
 		return res
 	}
 }
@@ -412,7 +417,7 @@ func (m *MainModel) updateTreeViewport(res model.Result) {
 	}
 
 	if len(ancestry) > 0 {
-		output.PrintTree(&b, ancestry, res.Children, true)
+		output.PrintTree(&b, ancestry, res.Tree, true)
 	}
 
 	if res.Process.Cmdline != "" {
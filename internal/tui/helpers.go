@@ -1,8 +1,34 @@
 package tui
 
-import "regexp"
+import (
+	"regexp"
+	"slices"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
 
 func stripAnsi(str string) string {
 	ansi := regexp.MustCompile(`[\x1b\x9b][[\\]()#;?]*(?:(?:(?:[a-zA-Z\d]*(?:;[a-zA-Z\d]*)*)?[\x07])|(?:(?:\d{1,4}(?:;\d{0,4})*)?[\dA-PRZcf-ntqry=><~]))`)
 	return ansi.ReplaceAllString(str, "")
 }
+
+// signalWarning returns a note for the terminate prompt when the selected
+// process ignores, blocks or handles SIGTERM. The other actions need none:
+// SIGKILL and SIGSTOP cannot be ignored, caught or blocked, and the kernel
+// resumes a stopped process as soon as SIGCONT is sent, whatever its masks.
+func signalWarning(res *model.Result) string {
+	if res == nil || res.Signals == nil {
+		return ""
+	}
+
+	const sig = "SIGTERM"
+	switch {
+	case slices.Contains(res.Signals.Ignored, sig):
+		return " (warning: " + sig + " is ignored, this will have no effect)"
+	case slices.Contains(res.Signals.Blocked, sig):
+		return " (warning: " + sig + " is blocked, delivery will be deferred)"
+	case slices.Contains(res.Signals.Caught, sig):
+		return " (note: " + sig + " is handled, shutdown may take a while)"
+	}
+	return ""
+}
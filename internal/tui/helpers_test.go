@@ -0,0 +1,28 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+func TestSignalWarning(t *testing.T) {
+	tests := []struct {
+		name    string
+		signals *model.SignalInfo
+		want    string
+	}{
+		{"no signal info", nil, ""},
+		{"term ignored", &model.SignalInfo{Ignored: []string{"SIGTERM"}, Blocked: []string{"SIGTERM"}}, " (warning: SIGTERM is ignored, this will have no effect)"},
+		{"term blocked", &model.SignalInfo{Blocked: []string{"SIGTERM"}}, " (warning: SIGTERM is blocked, delivery will be deferred)"},
+		{"term caught", &model.SignalInfo{Caught: []string{"SIGTERM"}}, " (note: SIGTERM is handled, shutdown may take a while)"},
+		{"term default", &model.SignalInfo{Caught: []string{"SIGCHLD"}}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := signalWarning(&model.Result{Signals: tt.signals}); got != tt.want {
+				t.Errorf("signalWarning() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/pranshuparmar/witr/internal/output"
 	"github.com/pranshuparmar/witr/pkg/model"
 )
 
@@ -131,6 +132,9 @@ type MainModel struct {
 	showAllPorts bool
 	version      string
 
+	// Masks secrets in command lines and environment views; nil with --reveal
+	redactor *output.Redactor
+
 	// Mouse double-click tracking
 	lastClickTime time.Time
 	lastClickX    int
@@ -246,12 +250,14 @@ func InitialModel(version string) MainModel {
 	}
 }
 
-func Start(version string) error {
+func Start(version string, redactor *output.Redactor) error {
 	if os.Getenv("COLORTERM") == "" {
 		os.Setenv("COLORTERM", "truecolor") //nolint:errcheck
 	}
 
-	p := tea.NewProgram(InitialModel(version), tea.WithAltScreen())
+	m := InitialModel(version)
+	m.redactor = redactor
+	p := tea.NewProgram(m, tea.WithAltScreen())
 	if _, err := p.Run(); err != nil {
 		return fmt.Errorf("error running tui: %w", err)
 	}
@@ -315,7 +315,7 @@ func (m MainModel) View() string {
 		case m.pendingAction == actionKill:
 			helpText = confirmStyle.Render(fmt.Sprintf("Kill PID %d? [y]es / [n]o", pid))
 		case m.pendingAction == actionTerm:
-			helpText = confirmStyle.Render(fmt.Sprintf("Terminate PID %d? [y]es / [n]o", pid))
+			helpText = confirmStyle.Render(fmt.Sprintf("Terminate PID %d?%s [y]es / [n]o", pid, signalWarning(m.selectedDetail)))
 		case m.pendingAction == actionPause:
 			helpText = confirmStyle.Render(fmt.Sprintf("Pause PID %d? [y]es / [n]o", pid))
 		case m.pendingAction == actionResume:
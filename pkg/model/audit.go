@@ -0,0 +1,24 @@
+package model
+
+// AuditResult is the outcome of a hidden-process scan
+type AuditResult struct {
+	PIDMax  int // Upper bound of the probed PID range
+	Listed  int // Processes visible when reading /proc
+	Hidden  []HiddenProcess
+	Skipped string `json:",omitempty"` // Why the scan could not run, if it did not
+}
+
+// HiddenProcess is a PID that is alive but missing from the /proc listing
+type HiddenProcess struct {
+	PID int
+
+	// How it was found: "stat", "kill", "children" or "ppid"
+	Methods []string
+
+	// Whatever could still be read about it
+	PPID    int    `json:",omitempty"`
+	Command string `json:",omitempty"`
+	Cmdline string `json:",omitempty"`
+	User    string `json:",omitempty"`
+	Exe     string `json:",omitempty"`
+}
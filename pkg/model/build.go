@@ -0,0 +1,15 @@
+package model
+
+import "time"
+
+// BuildInfo describes which build of a binary a process is running. Go
+// binaries carry module and VCS stamps; other ELF binaries only a build-id.
+type BuildInfo struct {
+	GoVersion    string    `json:",omitempty"`
+	Module       string    `json:",omitempty"`
+	Version      string    `json:",omitempty"`
+	Revision     string    `json:",omitempty"`
+	RevisionTime time.Time `json:",omitzero"`
+	Modified     bool      `json:",omitempty"`
+	BuildID      string    `json:",omitempty"`
+}
@@ -0,0 +1,32 @@
+package model
+
+import "time"
+
+// ContainerInfo describes the Docker or Podman container a process runs in,
+// as reported by the engine API
+type ContainerInfo struct {
+	Runtime        string // docker or podman
+	ID             string
+	Name           string
+	Image          string
+	ComposeProject string `json:",omitempty"`
+	ComposeService string `json:",omitempty"`
+
+	// Restart policy ("no", "always", "unless-stopped", "on-failure:5"), how
+	// often the engine restarted the container and when it last started it
+	RestartPolicy string    `json:",omitempty"`
+	RestartCount  int       `json:",omitempty"`
+	Restarting    bool      `json:",omitempty"`
+	StartedAt     time.Time `json:",omitzero"`
+
+	// Healthcheck is true when the image or run options define one; Health is
+	// its state ("starting", "healthy", "unhealthy") and HealthOutput what the
+	// last probe printed
+	Healthcheck   bool
+	Health        string `json:",omitempty"`
+	FailingStreak int    `json:",omitempty"`
+	HealthOutput  string `json:",omitempty"`
+
+	Networks []string `json:",omitempty"` // "name (ip)"
+	Ports    []string `json:",omitempty"` // "0.0.0.0:8080->80/tcp"
+}
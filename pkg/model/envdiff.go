@@ -0,0 +1,24 @@
+package model
+
+// EnvDiff explains where each environment variable of a process came from
+type EnvDiff struct {
+	ParentPID     int
+	ParentCommand string
+	Unit          string `json:",omitempty"` // systemd unit the process belongs to
+	Vars          []EnvVar
+}
+
+// EnvVar is one variable of the process environment and its origin
+type EnvVar struct {
+	Key   string
+	Value string
+
+	// Origin is "inherited", "added", "overridden" or "unit-defined"
+	Origin string
+
+	// Parent's value when the process overrides it
+	ParentValue string `json:",omitempty"`
+
+	// Where the unit declares it: "Environment=" or the EnvironmentFile= path
+	DefinedIn string `json:",omitempty"`
+}
@@ -0,0 +1,26 @@
+package model
+
+import "time"
+
+// GitInfo describes the git checkout a process is running from
+type GitInfo struct {
+	Root     string // Top of the work tree
+	Branch   string `json:",omitempty"` // Full branch name, empty when detached
+	Commit   string `json:",omitempty"` // HEAD commit SHA
+	Detached bool   `json:",omitempty"`
+
+	// Upstream remote of the branch, falling back to "origin"
+	Remote    string `json:",omitempty"`
+	RemoteURL string `json:",omitempty"`
+
+	// Linked worktree (git worktree add) or submodule checkout
+	Worktree  bool `json:",omitempty"`
+	Submodule bool `json:",omitempty"`
+
+	// When HEAD last moved (commit time, or the reflog entry if unavailable)
+	HeadTime time.Time `json:",omitzero"`
+
+	// Set when the executable lives inside the work tree
+	BinaryInRepo bool `json:",omitempty"`
+	BinaryNewer  bool `json:",omitempty"` // Executable modified after HEAD moved
+}
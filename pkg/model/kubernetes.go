@@ -0,0 +1,24 @@
+package model
+
+// KubernetesInfo describes the pod a process runs in, as recorded by the
+// node's container runtime or kubelet
+type KubernetesInfo struct {
+	Namespace   string
+	Pod         string
+	PodUID      string
+	Container   string
+	ContainerID string
+	Image       string `json:",omitempty"`
+
+	// QoS class derived from the pod's cgroup ("Guaranteed", "Burstable",
+	// "BestEffort")
+	QoSClass string
+
+	// Controller owning the pod ("Deployment", "StatefulSet", "DaemonSet",
+	// "Job", "CronJob", "ReplicaSet"), or "Node" for static pods
+	OwnerKind string `json:",omitempty"`
+	OwnerName string `json:",omitempty"`
+
+	// Times kubelet restarted this container within the pod
+	Restarts int `json:",omitempty"`
+}
@@ -0,0 +1,23 @@
+package model
+
+// PackageInfo names the OS package that installed a process's executable, or
+// marks it as a manual install outside any package manager.
+type PackageInfo struct {
+	Name    string `json:",omitempty"`
+	Version string `json:",omitempty"`
+	Manager string `json:",omitempty"` // dpkg, rpm, apk, pacman, nix, snap
+
+	// Manual is set for binaries under /usr/local or /opt owned by no package
+	Manual bool   `json:",omitempty"`
+	Prefix string `json:",omitempty"`
+
+	// Integrity compares the running executable with the package checksum
+	// and the file currently on disk
+	Integrity string `json:",omitempty"`
+}
+
+const (
+	IntegrityMatches  = "matches package"
+	IntegrityModified = "modified"
+	IntegrityReplaced = "replaced since process start"
+)
@@ -17,9 +17,16 @@ type Process struct {
 	WorkingDir string
 	GitRepo    string
 	GitBranch  string
+	Git        *GitInfo `json:",omitempty"`
 	Container  string
 	Service    string
 
+	// Engine-reported details of the Docker or Podman container, if any
+	ContainerInfo *ContainerInfo `json:",omitempty"`
+
+	// Pod, namespace and owning controller of a Kubernetes container, if any
+	Kubernetes *KubernetesInfo `json:",omitempty"`
+
 	// Network context
 	ListeningPorts []int
 	BindAddresses  []string
@@ -27,8 +34,16 @@ type Process struct {
 	// Health status ("healthy", "zombie", "stopped", "high-cpu", "high-mem")
 	Health string
 
-	// Forked status ("forked", "not-forked", "unknown")
-	Forked string
+	// Session, process group and controlling terminal, classified for job control
+	Session SessionInfo `json:",omitzero"`
+
+	// Process attached via ptrace (debugger, tracer, profiler), 0 if none
+	TracerPID  int    `json:",omitempty"`
+	TracerUser string `json:",omitempty"`
+
+	// True for kernel threads (PF_KTHREAD), which have no executable or cmdline
+	KernelThread bool `json:",omitempty"`
+
 	// Environment variables (key=value)
 	Env []string
 
@@ -45,6 +60,17 @@ type Process struct {
 	ThreadCount int        `json:",omitempty"`
 }
 
+// SessionInfo describes how a process relates to its session and controlling terminal
+type SessionInfo struct {
+	SID   int
+	PGID  int
+	TPGID int    // Foreground process group of the controlling terminal
+	TTY   string // Controlling terminal device (e.g. "pts/3"), empty if none
+
+	// Job control classification ("foreground", "background", "detached", "daemon")
+	Mode string
+}
+
 // MemoryInfo contains detailed memory information
 type MemoryInfo struct {
 	VMS    uint64  // Virtual memory size in bytes
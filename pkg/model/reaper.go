@@ -0,0 +1,36 @@
+package model
+
+import "time"
+
+// ZombieInfo explains why a defunct process is still in the process table
+type ZombieInfo struct {
+	// Parent that is expected to reap the zombie
+	ParentPID     int
+	ParentCommand string
+	ParentSource  Source
+
+	// Number of zombie children the parent is holding, including this one
+	ZombieCount int
+
+	// Start time of the oldest zombie child; the parent has neglected
+	// its children for at most this long
+	OldestStartedAt time.Time
+}
+
+// OrphanInfo explains why a process is parented by init or a subreaper
+// instead of the process that started it
+type OrphanInfo struct {
+	// Process that adopted the orphan
+	ReaperPID     int
+	ReaperCommand string
+
+	// True if the reaper is a PR_SET_CHILD_SUBREAPER manager rather than PID 1
+	Subreaper bool
+
+	// Session or process group leader the process was started under
+	LeaderPID     int
+	LeaderCommand string `json:",omitempty"`
+	LeaderAlive   bool
+
+	Explanation string
+}
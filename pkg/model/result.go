@@ -18,4 +18,25 @@ type Result struct {
 
 	// FileContext holds file descriptor and lock info
 	FileContext *FileContext
+
+	// Zombie explains which parent is failing to reap a defunct process
+	Zombie *ZombieInfo `json:",omitempty"`
+
+	// Orphan explains how a process was reparented to init or a subreaper
+	Orphan *OrphanInfo `json:",omitempty"`
+
+	// Signals holds ignored, caught, blocked and pending signals
+	Signals *SignalInfo `json:",omitempty"`
+
+	// Tracer describes the debugger or tracer attached to the process
+	Tracer *TracerInfo `json:",omitempty"`
+
+	// Build identifies the running binary (Go module/VCS stamps, ELF build-id)
+	Build *BuildInfo `json:",omitempty"`
+
+	// Package is the OS package that owns the executable
+	Package *PackageInfo `json:",omitempty"`
+
+	// Tree holds the full descendant tree (tree mode only)
+	Tree *ProcessTree `json:",omitempty"`
 }
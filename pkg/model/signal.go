@@ -0,0 +1,13 @@
+package model
+
+// SignalInfo holds the signal dispositions of a process, decoded to signal names
+type SignalInfo struct {
+	Ignored []string `json:",omitempty"`
+	Caught  []string `json:",omitempty"`
+	Blocked []string `json:",omitempty"`
+	Pending []string `json:",omitempty"`
+
+	// Human-readable consequences for termination signals,
+	// e.g. "SIGHUP ignored → survived terminal close (nohup?)"
+	Explanations []string `json:",omitempty"`
+}
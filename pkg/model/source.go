@@ -9,9 +9,12 @@ const (
 	SourceBsdRc          SourceType = "bsdrc"
 	SourceSupervisor     SourceType = "supervisor"
 	SourceCron           SourceType = "cron"
+	SourceAt             SourceType = "at"
 	SourceShell          SourceType = "shell"
+	SourceMultiplexer    SourceType = "multiplexer"
 	SourceWindowsService SourceType = "windows_service"
 	SourceInit           SourceType = "init"
+	SourceKernel         SourceType = "kernel"
 	SourceUnknown        SourceType = "unknown"
 )
 
@@ -0,0 +1,18 @@
+package model
+
+// TracerInfo describes the process attached to a target via ptrace
+type TracerInfo struct {
+	PID     int
+	Command string
+	Cmdline string
+	User    string
+
+	// Kind of tracer: "debugger", "syscall tracer", "library call tracer",
+	// "profiler", "checkpointer" or "unknown"
+	Kind        string
+	Description string
+
+	// How the tracer itself came to run
+	Ancestry []Process
+	Source   Source
+}
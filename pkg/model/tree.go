@@ -0,0 +1,23 @@
+package model
+
+// ProcessTree is a node in a process's descendant tree, built from a single snapshot
+type ProcessTree struct {
+	PID        int
+	Command    string
+	Cmdline    string `json:",omitempty"`
+	CPUPercent float64
+	MemoryRSS  uint64 // In bytes
+
+	// Roll-ups over this node and all of its descendants (including hidden ones)
+	TotalCPUPercent float64
+	TotalMemoryRSS  uint64
+
+	// Identical leaf siblings collapsed into this node; PIDs lists all of them
+	Count int   `json:",omitempty"`
+	PIDs  []int `json:",omitempty"`
+
+	// Descendants below the depth limit that were not expanded
+	Hidden int `json:",omitempty"`
+
+	Children []*ProcessTree `json:",omitempty"`
+}